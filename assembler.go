@@ -0,0 +1,84 @@
+package set
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+// Assembler creates a value from the components available in a Getter.
+//
+// When Fill() or FillByTag() encounter a Getter for a field whose type has a registered Assembler the
+// field is assigned the assembled value instead of being sub-filled; this allows struct types such
+// as time.Time to be created from a nested Getter.
+type Assembler func(getter Getter) (interface{}, error)
+
+// assemblers is the registry of Assembler by reflect.Type.
+var assemblers = &sync.Map{}
+
+func init() {
+	RegisterAssembler(time.Time{}, AssembleTime)
+}
+
+// RegisterAssembler registers fn as the Assembler for the type of sample; if sample is a pointer the
+// type at the end of the pointer chain is registered.  Registering a nil fn removes the Assembler
+// for the type.
+func RegisterAssembler(sample interface{}, fn Assembler) {
+	T := TypeCache.Stat(sample).Type
+	if T == nil {
+		return
+	} else if fn == nil {
+		assemblers.Delete(T)
+		return
+	}
+	assemblers.Store(T, fn)
+}
+
+// assemblerFor returns the Assembler registered for T.
+func assemblerFor(T reflect.Type) (Assembler, bool) {
+	if T == nil {
+		return nil, false
+	}
+	if fn, ok := assemblers.Load(T); ok {
+		return fn.(Assembler), true
+	}
+	return nil, false
+}
+
+// AssembleTime is the default Assembler for time.Time.  It creates a time.Time in UTC from the
+// following keys:
+//	year, month, day			// required
+//	hour, minute, second, nanosecond	// optional; default to zero
+//
+// Each component is type-coerced into an int so the Getter may return strings or numbers.
+func AssembleTime(getter Getter) (interface{}, error) {
+	var year, month, day, hour, minute, second, nanosecond int
+	components := []struct {
+		key      string
+		dest     *int
+		required bool
+	}{
+		{"year", &year, true},
+		{"month", &month, true},
+		{"day", &day, true},
+		{"hour", &hour, false},
+		{"minute", &minute, false},
+		{"second", &second, false},
+		{"nanosecond", &nanosecond, false},
+	}
+	for _, component := range components {
+		got := getter.Get(component.key)
+		if got == nil {
+			if component.required {
+				return nil, errors.Errorf("AssembleTime requires key [%v]", component.key)
+			}
+			continue
+		}
+		if err := V(component.dest).To(got); err != nil {
+			return nil, errors.Errorf("AssembleTime key [%v]: %v", component.key, err.Error())
+		}
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, time.UTC), nil
+}
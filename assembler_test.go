@@ -0,0 +1,110 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestAssembleTime(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Person struct {
+		Name    string
+		Born    time.Time
+		Married *time.Time
+	}
+	{
+		var p Person
+		getter := set.MapGetter(map[string]interface{}{
+			"Name": "Bob",
+			"Born": map[string]interface{}{
+				"year":  "1970",
+				"month": 3,
+				"day":   "21",
+			},
+			"Married": map[string]interface{}{
+				"year":   1999,
+				"month":  "12",
+				"day":    31,
+				"hour":   "23",
+				"minute": 59,
+			},
+		})
+		err := set.V(&p).Fill(getter)
+		chk.NoError(err)
+		chk.Equal("Bob", p.Name)
+		chk.Equal(time.Date(1970, 3, 21, 0, 0, 0, 0, time.UTC), p.Born)
+		chk.NotNil(p.Married)
+		chk.Equal(time.Date(1999, 12, 31, 23, 59, 0, 0, time.UTC), *p.Married)
+	}
+	{ // Missing required component.
+		var p Person
+		getter := set.MapGetter(map[string]interface{}{
+			"Born": map[string]interface{}{
+				"year":  1970,
+				"month": 3,
+			},
+		})
+		err := set.V(&p).Fill(getter)
+		chk.Error(err)
+	}
+	{ // Component that can not be coerced.
+		var p Person
+		getter := set.MapGetter(map[string]interface{}{
+			"Born": map[string]interface{}{
+				"year":  1970,
+				"month": "March",
+				"day":   21,
+			},
+		})
+		err := set.V(&p).Fill(getter)
+		chk.Error(err)
+	}
+}
+
+func TestRegisterAssembler(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Point struct {
+		X, Y int
+	}
+	type Shape struct {
+		Origin Point
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"Origin": map[string]interface{}{
+			"coords": []int{3, 4},
+		},
+	})
+	{ // Without an assembler Origin is sub-filled and coords is ignored.
+		var s Shape
+		err := set.V(&s).Fill(getter)
+		chk.NoError(err)
+		chk.Equal(Point{}, s.Origin)
+	}
+	set.RegisterAssembler(&Point{}, func(getter set.Getter) (interface{}, error) {
+		var coords []int
+		if err := set.V(&coords).To(getter.Get("coords")); err != nil {
+			return nil, err
+		}
+		return Point{X: coords[0], Y: coords[1]}, nil
+	})
+	{
+		var s Shape
+		err := set.V(&s).Fill(getter)
+		chk.NoError(err)
+		chk.Equal(Point{X: 3, Y: 4}, s.Origin)
+	}
+	set.RegisterAssembler(Point{}, nil)
+	{
+		var s Shape
+		err := set.V(&s).Fill(getter)
+		chk.NoError(err)
+		chk.Equal(Point{}, s.Origin)
+	}
+	set.RegisterAssembler(nil, nil) // No type; does nothing.
+}
@@ -0,0 +1,53 @@
+package set
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// atomics is the registry of struct types that are treated as single values rather than
+// containers of fields.
+var atomics = &sync.Map{}
+
+func init() {
+	RegisterAtomic(time.Time{}, big.Int{}, big.Float{}, big.Rat{}, url.URL{}, net.IPNet{})
+}
+
+// RegisterAtomic registers the types of samples as atomic; if a sample is a pointer the type at the end
+// of the pointer chain is registered.
+//
+// Atomic types are struct types that represent a single value, such as time.Time, and whose fields
+// should never be populated individually.  Atomic types are treated as leaves:
+//	+ Value.Fill() and Value.FillByTag() coerce them with Value.To() and will not sub-fill them
+//		from a Getter unless an Assembler is registered for the type.
+//	+ Value.FieldsFlattened() does not promote the fields of embedded atomic types.
+//	+ Mapper treats them as scalars.
+//
+// Value.To() assigns an atomic type from the same type or, if the atomic type implements
+// encoding.TextUnmarshaler, from a string.  time.Time is assigned from strings with the layouts in TimeFormats and
+// can also be assigned from a number representing a Unix epoch in seconds.  big.Int, big.Float, and big.Rat can
+// also be assigned from bools, numbers, numeric strings, and each other; big.Rat is assigned exactly.
+// url.URL and net.IPNet are assigned from strings with url.Parse() and net.ParseCIDR().
+//
+// time.Time, big.Int, big.Float, big.Rat, url.URL, and net.IPNet are registered by default; when built with Go 1.18 or
+// later netip.Addr, netip.AddrPort, and netip.Prefix are also registered.
+func RegisterAtomic(samples ...interface{}) {
+	for _, sample := range samples {
+		if T := TypeCache.Stat(sample).Type; T != nil {
+			atomics.Store(T, struct{}{})
+		}
+	}
+}
+
+// isAtomic returns true if T is a registered atomic type.
+func isAtomic(T reflect.Type) bool {
+	if T == nil {
+		return false
+	}
+	_, ok := atomics.Load(T)
+	return ok
+}
@@ -0,0 +1,10 @@
+//go:build go1.18
+// +build go1.18
+
+package set
+
+import "net/netip"
+
+func init() {
+	RegisterAtomic(netip.Addr{}, netip.AddrPort{}, netip.Prefix{})
+}
@@ -0,0 +1,60 @@
+//go:build go1.18
+// +build go1.18
+
+package set_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestAtomic_netip(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var addr netip.Addr
+		chk.NoError(set.V(&addr).To("192.168.0.1"))
+		chk.Equal(netip.MustParseAddr("192.168.0.1"), addr)
+		chk.Error(set.V(&addr).To("192.168.0.256"))
+		chk.Equal(netip.Addr{}, addr)
+	}
+	{
+		var prefix netip.Prefix
+		chk.NoError(set.V(&prefix).To("10.0.0.0/8"))
+		chk.Equal(netip.MustParsePrefix("10.0.0.0/8"), prefix)
+		var addrPort netip.AddrPort
+		chk.NoError(set.V(&addrPort).To("127.0.0.1:80"))
+		chk.Equal(uint16(80), addrPort.Port())
+	}
+	{ // Fill
+		type T struct {
+			Addr   netip.Addr
+			Prefix *netip.Prefix
+		}
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+			"Addr":   "192.168.0.1",
+			"Prefix": "192.168.0.0/24",
+		})))
+		chk.Equal(netip.MustParseAddr("192.168.0.1"), dest.Addr)
+		chk.Equal(netip.MustParsePrefix("192.168.0.0/24"), *dest.Prefix)
+		//
+		err := set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Addr": "not-an-address"}))
+		chk.Error(err)
+		chk.Contains(err.Error(), "netip.Addr")
+	}
+	{ // Atomic types are leaves and are not sub-filled or mapped into.
+		type T struct {
+			Addr netip.Addr
+		}
+		var dest T
+		err := set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Addr": map[string]interface{}{}}))
+		chk.Error(err)
+		mapping := set.DefaultMapper.Map(&dest)
+		chk.Equal([]string{"Addr"}, mapping.Keys)
+	}
+}
@@ -0,0 +1,331 @@
+package set_test
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// Version is a struct type that is registered as atomic in the tests below.
+type Version struct {
+	Major, Minor int
+}
+
+// UnmarshalText parses a string such as "1.2".
+func (v *Version) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(string(text), ".", 2)
+	if len(parts) != 2 {
+		return set.V(&v.Major).To("invalid")
+	}
+	if err := set.V(&v.Major).To(parts[0]); err != nil {
+		return err
+	}
+	return set.V(&v.Minor).To(parts[1])
+}
+
+func TestAtomic_time(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Name    string
+		Created time.Time
+		Updated *time.Time
+	}
+	expect := time.Date(2021, 5, 31, 12, 30, 0, 0, time.UTC)
+	{
+		var t T
+		getter := set.MapGetter(map[string]interface{}{
+			"Name":    "Bob",
+			"Created": "2021-05-31T12:30:00Z",
+			"Updated": "2021-05-31T12:30:00Z",
+		})
+		err := set.V(&t).Fill(getter)
+		chk.NoError(err)
+		chk.Equal("Bob", t.Name)
+		chk.True(expect.Equal(t.Created))
+		chk.NotNil(t.Updated)
+		chk.True(expect.Equal(*t.Updated))
+	}
+	{ // From time.Time and *time.Time
+		var t T
+		getter := set.MapGetter(map[string]interface{}{
+			"Created": expect,
+			"Updated": &expect,
+		})
+		err := set.V(&t).Fill(getter)
+		chk.NoError(err)
+		chk.Equal(expect, t.Created)
+		chk.Equal(expect, *t.Updated)
+	}
+	{ // Invalid strings return errors and leave the zero value.
+		t := T{Created: expect}
+		getter := set.MapGetter(map[string]interface{}{
+			"Created": "yesterday",
+		})
+		err := set.V(&t).Fill(getter)
+		chk.Error(err)
+		chk.True(t.Created.IsZero())
+	}
+	{ // Unsupported source types.
+		tm := expect
+		err := set.V(&tm).To(true)
+		chk.Error(err)
+		chk.True(tm.IsZero())
+	}
+}
+
+func TestAtomic_timeSlicesAndMaps(t *testing.T) {
+	chk := assert.New(t)
+	//
+	epoch := time.Date(2021, 5, 31, 12, 30, 0, 0, time.UTC)
+	{
+		var times []time.Time
+		err := set.V(&times).To([]interface{}{"2021-05-31T12:30:00Z", epoch.Unix(), uint64(epoch.Unix()), float64(epoch.Unix()) + 0.5})
+		chk.NoError(err)
+		chk.Equal([]time.Time{epoch, epoch, epoch, epoch.Add(time.Second / 2)}, times)
+	}
+	{
+		var times []*time.Time
+		err := set.V(&times).To([]string{"2021-05-31T12:30:00Z"})
+		chk.NoError(err)
+		chk.Equal(1, len(times))
+		chk.Equal(epoch, *times[0])
+	}
+	{
+		var times []time.Time
+		err := set.V(&times).To([]string{"2021-05-31T12:30:00Z", "tomorrow"})
+		chk.Error(err)
+		chk.Empty(times)
+	}
+	{
+		var times map[string]time.Time
+		err := set.V(&times).To(map[string]interface{}{
+			"string": "2021-05-31T12:30:00Z",
+			"int":    epoch.Unix(),
+		})
+		chk.NoError(err)
+		chk.Equal(map[string]time.Time{"string": epoch, "int": epoch}, times)
+	}
+	{
+		var times map[string]time.Time
+		err := set.V(&times).To(map[string]string{"bad": "tomorrow"})
+		chk.Error(err)
+		chk.Nil(times)
+	}
+	{ // Fill
+		type T struct {
+			Times   []time.Time
+			ByLabel map[string]time.Time
+		}
+		var t T
+		err := set.V(&t).Fill(set.GetterFunc(func(name string) interface{} {
+			switch name {
+			case "Times":
+				return []string{"2021-05-31T12:30:00Z"}
+			case "ByLabel":
+				return map[string]int64{"epoch": epoch.Unix()}
+			}
+			return nil
+		}))
+		chk.NoError(err)
+		chk.Equal([]time.Time{epoch}, t.Times)
+		chk.Equal(map[string]time.Time{"epoch": epoch}, t.ByLabel)
+	}
+}
+
+func TestAtomic_register(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Version Version
+	}
+	set.RegisterAtomic(&Version{})
+	{
+		var t T
+		err := set.V(&t).Fill(set.MapGetter(map[string]interface{}{
+			"Version": "1.2",
+		}))
+		chk.NoError(err)
+		chk.Equal(Version{1, 2}, t.Version)
+	}
+	{
+		var t T
+		err := set.V(&t).Fill(set.MapGetter(map[string]interface{}{
+			"Version": "1",
+		}))
+		chk.Error(err)
+	}
+	{ // Atomic types are not sub-filled.
+		var t T
+		err := set.V(&t).Fill(set.MapGetter(map[string]interface{}{
+			"Version": map[string]interface{}{
+				"Major": 1,
+				"Minor": 2,
+			},
+		}))
+		chk.Error(err)
+		chk.Equal(Version{}, t.Version)
+	}
+	{ // Embedded atomic types are not flattened.
+		type E struct {
+			time.Time
+			Version
+			Name string
+		}
+		var e E
+		var names []string
+		for _, f := range set.V(&e).FieldsFlattened() {
+			names = append(names, f.Field.Name)
+		}
+		chk.Equal([]string{"Time", "Version", "Name"}, names)
+	}
+	{ // Mapper treats registered atomic types as scalars.
+		type M struct {
+			Version Version
+			Other   struct {
+				A int
+			}
+		}
+		mapping := (&set.Mapper{Join: "_"}).Map(M{})
+		chk.Equal([]string{"Version", "Other_A"}, mapping.Keys)
+	}
+}
+
+func TestAtomic_big(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // Large decimal strings into *big.Int.
+		var b *big.Int
+		v := set.V(&b)
+		chk.NoError(v.To("123456789012345678901234567890"))
+		chk.NotNil(b)
+		chk.Equal("123456789012345678901234567890", b.String())
+		chk.NoError(v.To(" -98765432109876543210 "))
+		chk.Equal("-98765432109876543210", b.String())
+		chk.NoError(v.To(42))
+		chk.Equal("42", b.String())
+		chk.NoError(v.To(uint64(math.MaxUint64)))
+		chk.Equal("18446744073709551615", b.String())
+		chk.NoError(v.To(3.99))
+		chk.Equal("3", b.String())
+		chk.NoError(v.To(true))
+		chk.Equal("1", b.String())
+		chk.Error(v.To("12abc"))
+		chk.Equal("0", b.String())
+		chk.Error(v.To(math.Inf(1)))
+		chk.Equal("0", b.String())
+	}
+	{ // Set from *big.Int does not share memory.
+		src, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		var b big.Int
+		chk.NoError(set.V(&b).To(src))
+		src.Add(src, big.NewInt(1))
+		chk.Equal("123456789012345678901234567890", b.String())
+	}
+	{ // *big.Float
+		var f *big.Float
+		v := set.V(&f)
+		chk.NoError(v.To("1.5e100"))
+		chk.NotNil(f)
+		chk.Equal("1.5e+100", f.Text('g', 10))
+		chk.NoError(v.To(2.25))
+		chk.Equal("2.25", f.String())
+		chk.NoError(v.To(-7))
+		chk.Equal("-7", f.String())
+		n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		chk.NoError(v.To(n))
+		chk.Equal("123456789012345678901234567890", f.Text('f', 0))
+		chk.Error(v.To("abc"))
+		chk.Error(v.To(math.NaN()))
+	}
+	{ // Fill a struct with big fields.
+		type Account struct {
+			Balance *big.Int
+			Rate    *big.Float
+		}
+		var a Account
+		getter := set.MapGetter(map[string]interface{}{
+			"Balance": "99999999999999999999999999",
+			"Rate":    "0.0125",
+		})
+		chk.NoError(set.V(&a).Fill(getter))
+		chk.Equal("99999999999999999999999999", a.Balance.String())
+		chk.Equal("0.0125", a.Rate.String())
+	}
+	{ // Back into strings.
+		n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		var s string
+		chk.NoError(set.V(&s).To(n))
+		chk.Equal("123456789012345678901234567890", s)
+		chk.NoError(set.V(&s).To(big.NewFloat(2.5)))
+		chk.Equal("2.5", s)
+		chk.NoError(set.V(&s).To(*n))
+		chk.Equal("123456789012345678901234567890", s)
+	}
+}
+
+func TestAtomic_bigRat(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var r *big.Rat
+		v := set.V(&r)
+		chk.NoError(v.To("1/3"))
+		chk.NotNil(r)
+		chk.Equal("1/3", r.String())
+		chk.NoError(v.To(" 0.1 "))
+		chk.Equal("1/10", r.String()) // Exact, unlike float64.
+		chk.NoError(v.To(-7))
+		chk.Equal("-7/1", r.String())
+		chk.NoError(v.To(uint64(math.MaxUint64)))
+		chk.Equal("18446744073709551615/1", r.String())
+		chk.NoError(v.To(0.5))
+		chk.Equal("1/2", r.String())
+		chk.NoError(v.To(true))
+		chk.Equal("1/1", r.String())
+		chk.NoError(v.To(false))
+		chk.Equal("0/1", r.String())
+		chk.Error(v.To("one third"))
+		chk.Equal("0/1", r.String())
+		chk.Error(v.To(math.Inf(-1)))
+		chk.Error(v.To(math.NaN()))
+	}
+	{ // From and into the other big types without sharing memory.
+		n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		var r big.Rat
+		chk.NoError(set.V(&r).To(n))
+		n.Add(n, big.NewInt(1))
+		chk.Equal("123456789012345678901234567890/1", r.String())
+		chk.NoError(set.V(&r).To(big.NewFloat(0.25)))
+		chk.Equal("1/4", r.String())
+		chk.Error(set.V(&r).To(new(big.Float).SetInf(false)))
+		src := big.NewRat(22, 7)
+		chk.NoError(set.V(&r).To(src))
+		src.SetInt64(1)
+		chk.Equal("22/7", r.String())
+		//
+		var i big.Int
+		chk.NoError(set.V(&i).To(big.NewRat(-22, 7)))
+		chk.Equal("-3", i.String())
+		var f big.Float
+		chk.NoError(set.V(&f).To(big.NewRat(1, 4)))
+		chk.Equal("0.25", f.String())
+	}
+	{ // Fill and back into strings.
+		type Invoice struct {
+			Total *big.Rat
+		}
+		var invoice Invoice
+		chk.NoError(set.V(&invoice).Fill(set.MapGetter(map[string]interface{}{"Total": "19.99"})))
+		chk.Equal("1999/100", invoice.Total.String())
+		var s string
+		chk.NoError(set.V(&s).To(invoice.Total))
+		chk.Equal("1999/100", s)
+	}
+}
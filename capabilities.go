@@ -0,0 +1,35 @@
+package set
+
+import (
+	"reflect"
+)
+
+// Capabilities describes which operations a *Value supports; see Value.Capabilities().
+type Capabilities struct {
+	// Append is true if Value.Append() and Value.ToAppend() are supported; i.e. Value is a writable slice or map.
+	Append bool
+	// Fields is true if Value.Fields() returns the fields of a struct.
+	Fields bool
+	// NewElem is true if Value.NewElem() is supported.
+	NewElem bool
+	// Zero is true if Value.Zero() is supported.
+	Zero bool
+	// To is true if Value.To() is supported.
+	To bool
+}
+
+// Capabilities returns the operations supported by Value.  Generic code can inspect the returned
+// value instead of calling a method and checking for an error.
+func (me *Value) Capabilities() Capabilities {
+	if me == nil {
+		return Capabilities{}
+	}
+	writable := me.CanWrite && me.Kind != reflect.Invalid
+	return Capabilities{
+		Append:  writable && (me.IsSlice || me.IsMap),
+		Fields:  me.IsStruct,
+		NewElem: me.ElemTypeInfo.Kind != reflect.Invalid,
+		Zero:    writable,
+		To:      writable && me.original != nil,
+	}
+}
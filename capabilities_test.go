@@ -0,0 +1,48 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestValue_capabilities(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A int
+	}
+	var i int
+	var s []int
+	var m map[string]int
+	var st T
+	for _, test := range []struct {
+		Name   string
+		Value  *set.Value
+		Expect set.Capabilities
+	}{
+		{"nil", nil, set.Capabilities{}},
+		{"invalid", set.V(nil), set.Capabilities{}},
+		{"scalar", set.V(&i), set.Capabilities{Zero: true, To: true}},
+		{"scalar read only", set.V(i), set.Capabilities{}},
+		{"slice", set.V(&s), set.Capabilities{Append: true, NewElem: true, Zero: true, To: true}},
+		{"slice read only", set.V(s), set.Capabilities{NewElem: true}},
+		{"map", set.V(&m), set.Capabilities{Append: true, NewElem: true, Zero: true, To: true}},
+		{"struct", set.V(&st), set.Capabilities{Fields: true, Zero: true, To: true}},
+		{"struct read only", set.V(st), set.Capabilities{Fields: true}},
+	} {
+		chk.Equal(test.Expect, test.Value.Capabilities(), test.Name)
+	}
+	{ // Capabilities agree with the methods.
+		caps := set.V(&i).Capabilities()
+		chk.Equal(caps.Append, set.V(&i).Append(1) == nil)
+		_, err := set.V(&i).NewElem()
+		chk.Equal(caps.NewElem, err == nil)
+		caps = set.V(&s).Capabilities()
+		chk.Equal(caps.Append, set.V(&s).Append(1) == nil)
+		_, err = set.V(&s).NewElem()
+		chk.Equal(caps.NewElem, err == nil)
+	}
+}
@@ -1,9 +1,16 @@
 package set
 
 import (
+	"encoding"
 	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nofeaturesonlybugs/errors"
 )
@@ -18,39 +25,44 @@ import (
 //	+ It is taken as a given that these functions are called within a defer...recover construct
 //		and can not crash the program.
 //	+ It is taken as a given that these functions do not need to zero out target to a zero
-//		value as that is done before they are called.
+//		value as that is done by the caller when they return an error.
+//	+ On success these functions must assign target.
+//
+// Functions that parse strings into bool, float, int, or uint ignore leading and trailing whitespace; a string
+// that is empty or only whitespace can not be parsed and returns an error.  Strict is the default, rather than
+// treating such strings as the zero value, so existing callers that rely on the error are unaffected; the lenient
+// behavior is opt-in with Options.EmptyAsZero, which Value.To() applies before these functions are called.
+//
+// Coercions to and from bool follow Go truthiness and are symmetric:
+//	+ Any int, uint, or float of any width coerces to true if it is not equal to zero and false otherwise;
+//		negative zero is false and NaN is true because NaN != 0.
+//	+ A string coerces with strconv.ParseBool(); numeric strings are not interpreted as numbers.
+//	+ true coerces to 1 and false coerces to 0 for every int, uint, and float type.
 var coercions = map[string]func(reflect.Value, reflect.Value) error{
 	"float-to-bool": func(target reflect.Value, value reflect.Value) error {
-		if value.Float() != 0 {
-			target.SetBool(true)
-		} else {
-			target.SetBool(false)
-		}
+		target.SetBool(numericToBool(value))
 		return nil
 	},
 	"int-to-bool": func(target reflect.Value, value reflect.Value) error {
-		if value.Int() != 0 {
-			target.SetBool(true)
-		} else {
-			target.SetBool(false)
-		}
+		target.SetBool(numericToBool(value))
 		return nil
 	},
 	"string-to-bool": func(target reflect.Value, value reflect.Value) error {
 		var err error
 		var parsed bool
-		if parsed, err = strconv.ParseBool(value.String()); err != nil {
+		if parsed, err = strconv.ParseBool(strings.TrimSpace(value.String())); err != nil {
 			return errors.Go(err)
 		}
 		target.SetBool(parsed)
 		return nil
 	},
 	"uint-to-bool": func(target reflect.Value, value reflect.Value) error {
-		if value.Uint() != 0 {
-			target.SetBool(true)
-		} else {
-			target.SetBool(false)
-		}
+		target.SetBool(numericToBool(value))
+		return nil
+	},
+
+	"bool-to-bool": func(target reflect.Value, value reflect.Value) error {
+		target.SetBool(value.Bool())
 		return nil
 	},
 
@@ -62,6 +74,10 @@ var coercions = map[string]func(reflect.Value, reflect.Value) error{
 		}
 		return nil
 	},
+	"float-to-float": func(target reflect.Value, value reflect.Value) error {
+		target.SetFloat(value.Float())
+		return nil
+	},
 	"int-to-float": func(target reflect.Value, value reflect.Value) error {
 		target.SetFloat(float64(value.Int()))
 		return nil
@@ -69,7 +85,7 @@ var coercions = map[string]func(reflect.Value, reflect.Value) error{
 	"string-to-float": func(target reflect.Value, value reflect.Value) error {
 		var err error
 		var parsed float64
-		if parsed, err = strconv.ParseFloat(value.String(), target.Type().Bits()); err != nil {
+		if parsed, err = strconv.ParseFloat(strings.TrimSpace(value.String()), target.Type().Bits()); err != nil {
 			return errors.Go(err)
 		}
 		target.SetFloat(parsed)
@@ -89,20 +105,30 @@ var coercions = map[string]func(reflect.Value, reflect.Value) error{
 		return nil
 	},
 	"float-to-int": func(target reflect.Value, value reflect.Value) error {
-		target.SetInt(int64(value.Float()))
+		return setFloatAsInt(target, value.Float())
+	},
+	"int-to-int": func(target reflect.Value, value reflect.Value) error {
+		if target.OverflowInt(value.Int()) {
+			return overflowError(target, value.Int())
+		}
+		target.SetInt(value.Int())
 		return nil
 	},
 	"string-to-int": func(target reflect.Value, value reflect.Value) error {
-		if parsed, err := strconv.ParseInt(value.String(), 0, target.Type().Bits()); err == nil {
+		str := strings.TrimSpace(value.String())
+		if parsed, err := strconv.ParseInt(str, 0, target.Type().Bits()); err == nil {
 			target.SetInt(parsed)
-		} else if parsedFloat, err := strconv.ParseFloat(value.String(), target.Type().Bits()); err == nil {
-			target.SetInt(int64(parsedFloat))
+		} else if parsedFloat, err := strconv.ParseFloat(str, target.Type().Bits()); err == nil {
+			return setFloatAsInt(target, parsedFloat)
 		} else {
 			return errors.Go(err)
 		}
 		return nil
 	},
 	"uint-to-int": func(target reflect.Value, value reflect.Value) error {
+		if value.Uint() > math.MaxInt64 || target.OverflowInt(int64(value.Uint())) {
+			return overflowError(target, value.Uint())
+		}
 		target.SetInt(int64(value.Uint()))
 		return nil
 	},
@@ -116,29 +142,35 @@ var coercions = map[string]func(reflect.Value, reflect.Value) error{
 		return nil
 	},
 	"float-to-uint": func(target reflect.Value, value reflect.Value) error {
-		if value.Float() < 0 {
-			return errors.Errorf("Can not coerce negative float to uint.")
-		}
-		target.SetUint(uint64(value.Float()))
-		return nil
+		return setFloatAsUint(target, value.Float())
 	},
 	"int-to-uint": func(target reflect.Value, value reflect.Value) error {
 		if value.Int() < 0 {
 			return errors.Errorf("Can not coerce negative int to uint.")
+		} else if target.OverflowUint(uint64(value.Int())) {
+			return overflowError(target, value.Int())
 		}
 		target.SetUint(uint64(value.Int()))
 		return nil
 	},
+	"uint-to-uint": func(target reflect.Value, value reflect.Value) error {
+		if target.OverflowUint(value.Uint()) {
+			return overflowError(target, value.Uint())
+		}
+		target.SetUint(value.Uint())
+		return nil
+	},
 	"string-to-uint": func(target reflect.Value, value reflect.Value) error {
 		var parsed uint64
 		var parsedFloat float64
 		var err error
-		if len(value.String()) > 0 && rune(value.String()[0]) == '-' {
+		str := strings.TrimSpace(value.String())
+		if len(str) > 0 && rune(str[0]) == '-' {
 			return errors.Errorf("Can not coerce negative number to uint.")
-		} else if parsed, err = strconv.ParseUint(value.String(), 0, target.Type().Bits()); err == nil {
+		} else if parsed, err = strconv.ParseUint(str, 0, target.Type().Bits()); err == nil {
 			target.SetUint(parsed)
-		} else if parsedFloat, err = strconv.ParseFloat(value.String(), target.Type().Bits()); err == nil {
-			target.SetUint(uint64(parsedFloat))
+		} else if parsedFloat, err = strconv.ParseFloat(str, target.Type().Bits()); err == nil {
+			return setFloatAsUint(target, parsedFloat)
 		} else {
 			return errors.Go(err)
 		}
@@ -163,11 +195,61 @@ var coercions = map[string]func(reflect.Value, reflect.Value) error{
 	},
 }
 
+// overflowError returns the error for a number that does not fit in target.
+func overflowError(target reflect.Value, number interface{}) error {
+	return errors.Errorf("Value %v overflows %v.", number, target.Type())
+}
+
+// setFloatAsInt assigns f, truncated toward zero, to the int target; an error is returned if f is NaN, infinite,
+// or does not fit in target.
+func setFloatAsInt(target reflect.Value, f float64) error {
+	// -2^63 is exactly representable and the smallest int64; 2^63 is the smallest float64 that overflows.
+	if t := math.Trunc(f); math.IsNaN(f) || t < math.MinInt64 || t >= -math.MinInt64 || target.OverflowInt(int64(t)) {
+		return overflowError(target, f)
+	}
+	target.SetInt(int64(f))
+	return nil
+}
+
+// setFloatAsUint assigns f, truncated toward zero, to the uint target; an error is returned if f is negative,
+// NaN, infinite, or does not fit in target.
+func setFloatAsUint(target reflect.Value, f float64) error {
+	if f < 0 {
+		return errors.Errorf("Can not coerce negative float to uint.")
+	} else if t := math.Trunc(f); math.IsNaN(f) || t >= 1<<64 || target.OverflowUint(uint64(t)) {
+		return overflowError(target, f)
+	}
+	target.SetUint(uint64(f))
+	return nil
+}
+
+// numericToBool returns true if the int, uint, or float in value is not equal to zero.
+func numericToBool(value reflect.Value) bool {
+	switch numericKind(value.Kind()) {
+	case "int":
+		return value.Int() != 0
+	case "uint":
+		return value.Uint() != 0
+	case "float":
+		return value.Float() != 0
+	}
+	return false
+}
+
 // coerceType accepts a reflect.Value and returns a simplified logical type; for example float32 and float64
 // are condensed into float; all ints (int, int8, int16, ...) are condensed into int.  Likewise for uint types.
 // The second return value indicates if this type can be type-coerced.
 func coerceType(v reflect.Value) (string, bool) {
-	switch v.Kind() {
+	if name, ok := coerceKind(v.Kind()); ok {
+		return name, true
+	}
+	return v.Type().String(), false
+}
+
+// coerceKind returns the simplified logical type for K as described by coerceType; the second return value is
+// false if K can not be type-coerced.
+func coerceKind(K reflect.Kind) (string, bool) {
+	switch K {
 	case reflect.Bool:
 		return "bool", true
 
@@ -202,26 +284,503 @@ func coerceType(v reflect.Value) (string, bool) {
 		return "string", true
 
 	default:
-		return v.Type().String(), false
+		return "", false
 	}
 }
 
-// coerce coerces the data in value to the correct type and assigns it to target.
+// coerce coerces the data in value to the correct type and assigns it to target; labels registered with
+// RegisterEnumNames are honored and if target is a registered enum type then the coerced value must be one of the
+// enum's allowed values.
 func coerce(target reflect.Value, value reflect.Value) error {
-	to, _ := coerceType(target)
-	from, _ := coerceType(value)
-	if fn, ok := coercions[from+"-to-"+to]; ok {
-		var err error
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					err = errors.Errorf("Recovered %v", r)
-				}
-			}()
+	if handled, err := coerceEnumName(target, value); handled {
+		if err != nil {
+			if target.CanSet() {
+				target.Set(reflect.Zero(target.Type()))
+			}
+			return newCoerceError(target, value, err)
+		}
+		return checkEnum(target)
+	} else if err := coerceScalar(target, value); err != nil {
+		return err
+	}
+	return checkEnum(target)
+}
+
+// coerceScalar coerces the data in value to the correct type and assigns it to target.  When target and value
+// are different types of the same kind, such as string and a named string type, value is converted.
+func coerceScalar(target reflect.Value, value reflect.Value) error {
+	fn := coercionFor(value.Type(), target.Type())
+	if fn == nil {
+		if target.CanSet() {
 			target.Set(reflect.Zero(target.Type()))
-			err = fn(target, value)
+		}
+		to, _ := coerceType(target)
+		from, _ := coerceType(value)
+		return newCoerceError(target, value, errors.Errorf("Type coercion from %v to %v unsupported.", from, to))
+	}
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("Recovered %v", r)
+			}
 		}()
+		err = fn(target, value)
+	}()
+	if err != nil && target.CanSet() {
+		target.Set(reflect.Zero(target.Type()))
+	}
+	return newCoerceError(target, value, err)
+}
+
+// coercionTable holds the function from coercions for each pair of source and destination kinds; it is
+// indexed as coercionTable[source kind][destination kind] and a nil entry means the kinds can not be coerced.
+//
+// Performance note:
+//	Selecting a coercion once required calling coerceType() twice and building the string key into coercions
+//	for every call.  The selection depends only on the kinds of the two types so it is made once, when the
+//	package is initialized, and each call becomes an array lookup.  A cache keyed by the pair of reflect.Type
+//	was measured and was slower than the original map lookup because of the cost of hashing the key.
+var coercionTable [reflect.UnsafePointer + 1][reflect.UnsafePointer + 1]func(target reflect.Value, value reflect.Value) error
+
+func init() {
+	for src := range coercionTable {
+		from, _ := coerceKind(reflect.Kind(src))
+		for dst := range coercionTable[src] {
+			to, _ := coerceKind(reflect.Kind(dst))
+			coercionTable[src][dst] = coercions[from+"-to-"+to]
+		}
+	}
+}
+
+// convertCoercion assigns value to target with reflect.Value.Convert(); it is used for different types of the
+// same kind.
+func convertCoercion(target reflect.Value, value reflect.Value) error {
+	target.Set(value.Convert(target.Type()))
+	return nil
+}
+
+// coercionFor returns the function that coerces values of type src into dst; nil is returned if the types can
+// not be coerced.
+func coercionFor(src, dst reflect.Type) func(target reflect.Value, value reflect.Value) error {
+	srcKind, dstKind := src.Kind(), dst.Kind()
+	if srcKind == dstKind && src != dst && (isScalarKind(srcKind) || src.ConvertibleTo(dst)) {
+		return convertCoercion // Different types of the same scalar kind are always convertible.
+	}
+	return coercionTable[srcKind][dstKind]
+}
+
+// coerceAtomic coerces the data in value into target where target is a registered atomic type.  big.Int and
+// big.Float targets are handled by coerceBig and big.Rat targets by coerceBigRat; for other targets value is
+// assigned directly if its type is assignable to target and time.Time targets are handled by coerceTime.  Any
+// value not handled by these is passed to unmarshalText, which handles strings.
+func coerceAtomic(target reflect.Value, value reflect.Value) error {
+	return newCoerceError(target, value, coerceAtomicValue(target, value))
+}
+
+// coerceAtomicValue performs the coercion for coerceAtomic.
+func coerceAtomicValue(target reflect.Value, value reflect.Value) error {
+	if target.Type() == typeBigInt || target.Type() == typeBigFloat {
+		if handled, err := coerceBig(target, value); handled {
+			return err
+		}
+	} else if target.Type() == typeBigRat {
+		if handled, err := coerceBigRat(target, value); handled {
+			return err
+		}
+	} else if value.Type().AssignableTo(target.Type()) {
+		target.Set(value)
+		return nil
+	} else if target.Type() == typeTime {
+		if handled, err := coerceTime(target, value, time.Second); handled {
+			return err
+		}
+	}
+	if handled, err := unmarshalText(target, value); handled {
 		return err
 	}
-	return errors.Errorf("Type coercion from %v to %v unsupported.", from, to)
+	return errors.Errorf("Type coercion from %v to %v unsupported.", value.Type(), target.Type())
+}
+
+// typeURL and typeIPNet are the reflect.Types for url.URL and net.IPNet.
+var (
+	typeURL   = reflect.TypeOf(url.URL{})
+	typeIPNet = reflect.TypeOf(net.IPNet{})
+)
+
+// unmarshalText unmarshals value into target when value is a string and target implements
+// encoding.TextUnmarshaler.  url.URL and net.IPNet do not implement encoding.TextUnmarshaler and are parsed with
+// url.Parse() and net.ParseCIDR() instead.  The first return value is false if value was not handled.
+func unmarshalText(target reflect.Value, value reflect.Value) (bool, error) {
+	if value.Kind() != reflect.String || !target.CanAddr() {
+		return false, nil
+	}
+	switch target.Type() {
+	case typeURL:
+		parsed, err := url.Parse(value.String())
+		if err != nil {
+			return true, errors.Go(err)
+		}
+		target.Set(reflect.ValueOf(parsed).Elem())
+		return true, nil
+	case typeIPNet:
+		_, parsed, err := net.ParseCIDR(strings.TrimSpace(value.String()))
+		if err != nil {
+			return true, errors.Go(err)
+		}
+		target.Set(reflect.ValueOf(parsed).Elem())
+		return true, nil
+	}
+	if unmarshaler, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return true, errors.Go(unmarshaler.UnmarshalText([]byte(value.String())))
+	}
+	return false, nil
+}
+
+// marshalText returns the text of value if value or a pointer to value implements encoding.TextMarshaler; the
+// second return value is false if value does not implement it or returns an error.
+func marshalText(value reflect.Value) (string, bool) {
+	if marshaler, ok := addressable(value).Addr().Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text), true
+		}
+	}
+	return "", false
+}
+
+// stringerText returns the result of String() if value or a pointer to value implements fmt.Stringer; the second
+// return value is false if it does not.
+func stringerText(value reflect.Value) (string, bool) {
+	if stringer, ok := addressable(value).Addr().Interface().(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+	return "", false
+}
+
+// addressable returns value if it is addressable; otherwise it returns an addressable copy of value.
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value
+	}
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr.Elem()
+}
+
+// typeTime is the reflect.Type for time.Time.
+var typeTime = reflect.TypeOf(time.Time{})
+
+// typeDuration is the reflect.Type for time.Duration.
+var typeDuration = reflect.TypeOf(time.Duration(0))
+
+// TimeFormats is the list of layouts, in order of preference, used to parse strings into time.Time when a field
+// does not specify its own layout with a time or timeformat struct-tag.  The first layout that parses the string
+// is used.  Append to it during program initialization to accept additional formats:
+//	set.TimeFormats = append(set.TimeFormats, "2006-01-02")
+//
+// If TimeFormats is empty strings are parsed with time.Time's UnmarshalText().
+var TimeFormats = []string{time.RFC3339Nano}
+
+// timeLayout returns the layout in the time or timeformat struct-tag of field; the second return value is
+// false if field is not a time.Time, or pointer to time.Time, or it has neither tag.
+func timeLayout(field Field) (string, bool) {
+	if field.Value.Type != typeTime {
+		return "", false
+	} else if layout, ok := field.Field.Tag.Lookup("time"); ok {
+		return layout, true
+	}
+	return field.Field.Tag.Lookup("timeformat")
+}
+
+// parseTimeLayout returns value parsed as a time.Time with layout if value is a string or pointer to string;
+// otherwise value is returned unchanged.  The time is in UTC unless the layout contains a time zone.
+func parseTimeLayout(layout string, value interface{}) (interface{}, error) {
+	var str string
+	switch tt := value.(type) {
+	case string:
+		str = tt
+	case *string:
+		if tt == nil {
+			return value, nil
+		}
+		str = *tt
+	default:
+		return value, nil
+	}
+	parsed, err := time.Parse(layout, strings.TrimSpace(str))
+	if err != nil {
+		return nil, errors.Go(err)
+	}
+	return parsed, nil
+}
+
+// coerceTime coerces numeric values into the time.Time target as a Unix epoch in unit, which must evenly divide
+// time.Second; the resulting time is in UTC.  Strings are parsed with the layouts in TimeFormats.  The first
+// return value is false if value was not handled.
+func coerceTime(target reflect.Value, value reflect.Value, unit time.Duration) (bool, error) {
+	var t time.Time
+	switch value.Kind() {
+	case reflect.String:
+		if len(TimeFormats) == 0 {
+			return false, nil
+		}
+		str := strings.TrimSpace(value.String())
+		for _, layout := range TimeFormats {
+			if parsed, err := time.Parse(layout, str); err == nil {
+				target.Set(reflect.ValueOf(parsed))
+				return true, nil
+			}
+		}
+		return true, errors.Errorf("String %v does not match a layout in TimeFormats", str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		t = epochTime(value.Int(), unit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		t = epochTime(int64(value.Uint()), unit)
+	case reflect.Float32, reflect.Float64:
+		whole, frac := math.Modf(value.Float())
+		t = epochTime(int64(whole), unit).Add(time.Duration(math.Round(frac * float64(unit))))
+	default:
+		return false, nil
+	}
+	target.Set(reflect.ValueOf(t.UTC()))
+	return true, nil
+}
+
+// epochTime returns the time that is n units after the Unix epoch; unit must evenly divide time.Second.
+func epochTime(n int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	return time.Unix(n/perSecond, n%perSecond*int64(unit))
+}
+
+// unixEpoch returns t as the number of units since the Unix epoch; unit must evenly divide time.Second.  The
+// returned value is a float64, including the fractional unit, if float is true; otherwise it is an int64.
+func unixEpoch(t time.Time, unit time.Duration, float bool) reflect.Value {
+	perSecond := int64(time.Second / unit)
+	if float {
+		return reflect.ValueOf(float64(t.Unix())*float64(perSecond) + float64(t.Nanosecond())/float64(unit))
+	}
+	return reflect.ValueOf(t.Unix()*perSecond + int64(t.Nanosecond())/int64(unit))
+}
+
+// typeBigInt, typeBigFloat, and typeBigRat are the reflect.Type for big.Int, big.Float, and big.Rat.
+var (
+	typeBigInt   = reflect.TypeOf(big.Int{})
+	typeBigFloat = reflect.TypeOf(big.Float{})
+	typeBigRat   = reflect.TypeOf(big.Rat{})
+)
+
+// coerceBig coerces bool, numeric, string, big.Int, and big.Float values into the big.Int or big.Float target
+// with the target's Set methods so the target never shares memory with value.  Floats are truncated when coerced
+// into big.Int.  The first return value is false if value was not handled.
+func coerceBig(target reflect.Value, value reflect.Value) (handled bool, err error) {
+	if !target.CanAddr() {
+		return false, nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("Recovered %v", r)
+		}
+	}()
+	f := new(big.Float)
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			f.SetInt64(1)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.SetInt64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f.SetUint64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		f.SetFloat64(value.Float())
+	case reflect.String:
+		str := strings.TrimSpace(value.String())
+		if z, ok := target.Addr().Interface().(*big.Int); ok {
+			if _, ok = z.SetString(str, 0); !ok {
+				z.SetInt64(0)
+				return true, errors.Errorf("Can not coerce %v to big.Int.", value.String())
+			}
+			return true, nil
+		} else if _, ok = f.SetString(str); !ok {
+			return true, errors.Errorf("Can not coerce %v to big.Float.", value.String())
+		}
+	case reflect.Struct:
+		switch src := value.Interface().(type) {
+		case big.Int:
+			if z, ok := target.Addr().Interface().(*big.Int); ok {
+				z.Set(&src)
+				return true, nil
+			}
+			f.SetInt(&src)
+		case big.Float:
+			f.Set(&src)
+		case big.Rat:
+			if z, ok := target.Addr().Interface().(*big.Int); ok {
+				z.Quo(src.Num(), src.Denom()) // Truncated toward zero like the other sources.
+				return true, nil
+			}
+			f.SetRat(&src)
+		default:
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+	switch z := target.Addr().Interface().(type) {
+	case *big.Int:
+		if f.IsInf() {
+			z.SetInt64(0)
+			return true, errors.Errorf("Can not coerce infinity to big.Int.")
+		}
+		f.Int(z)
+	case *big.Float:
+		z.SetPrec(0).Set(f) // Precision is reset so z takes the precision of f.
+	}
+	return true, nil
+}
+
+// coerceBigRat coerces bool, numeric, string, big.Int, big.Float, and big.Rat values into the big.Rat target
+// exactly; the target never shares memory with value.  The first return value is false if value was not handled.
+func coerceBigRat(target reflect.Value, value reflect.Value) (bool, error) {
+	if !target.CanAddr() {
+		return false, nil
+	}
+	z := target.Addr().Interface().(*big.Rat)
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			z.SetInt64(1)
+		} else {
+			z.SetInt64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		z.SetInt64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		z.SetUint64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		if z.SetFloat64(value.Float()) == nil {
+			z.SetInt64(0)
+			return true, errors.Errorf("Can not coerce %v to big.Rat.", value.Float())
+		}
+	case reflect.String:
+		if _, ok := z.SetString(strings.TrimSpace(value.String())); !ok {
+			z.SetInt64(0)
+			return true, errors.Errorf("Can not coerce %v to big.Rat.", value.String())
+		}
+	case reflect.Struct:
+		switch src := value.Interface().(type) {
+		case big.Int:
+			z.SetInt(&src)
+		case big.Float:
+			if src.IsInf() {
+				z.SetInt64(0)
+				return true, errors.Errorf("Can not coerce infinity to big.Rat.")
+			}
+			src.Rat(z)
+		case big.Rat:
+			z.Set(&src)
+		default:
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// isScalarKind returns true if K is bool, a number, or string.
+func isScalarKind(K reflect.Kind) bool {
+	return K == reflect.Bool || K == reflect.String || numericKind(K) != ""
+}
+
+// isNegative returns true if value is a negative number or a string that parses as one.
+func isNegative(value reflect.Value) bool {
+	switch numericKind(value.Kind()) {
+	case "int":
+		return value.Int() < 0
+	case "float":
+		return value.Float() < 0
+	}
+	if value.Kind() == reflect.String {
+		f, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+		return err == nil && f < 0
+	}
+	return false
+}
+
+// numericKind returns "int", "uint", or "float" for numeric kinds; otherwise it returns the empty string.
+func numericKind(K reflect.Kind) string {
+	switch K {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	}
+	return ""
+}
+
+// convertNumericSlice converts the numeric slice src into a new slice of type T whose elements are also numeric;
+// the elements are set directly on a preallocated slice instead of being coerced individually.
+//
+// The second return value is false if any element can not be converted without overflow, truncation of a
+// negative number into an unsigned type, or loss of a NaN or infinity; the caller should then fall back to
+// coercing each element, which produces the same result as this function for all other values.
+func convertNumericSlice(T reflect.Type, src reflect.Value) (reflect.Value, bool) {
+	to, from := numericKind(T.Elem().Kind()), numericKind(src.Type().Elem().Kind())
+	if to == "" || from == "" {
+		return reflect.Value{}, false
+	}
+	size := src.Len()
+	if size == 0 {
+		return reflect.Zero(T), true
+	}
+	rv := reflect.MakeSlice(T, size, size)
+	for k := 0; k < size; k++ {
+		elem, target := src.Index(k), rv.Index(k)
+		switch from + "-to-" + to {
+		case "int-to-int":
+			if n := elem.Int(); !target.OverflowInt(n) {
+				target.SetInt(n)
+				continue
+			}
+		case "int-to-uint":
+			if n := elem.Int(); n >= 0 && !target.OverflowUint(uint64(n)) {
+				target.SetUint(uint64(n))
+				continue
+			}
+		case "uint-to-int":
+			if n := elem.Uint(); n <= math.MaxInt64 && !target.OverflowInt(int64(n)) {
+				target.SetInt(int64(n))
+				continue
+			}
+		case "uint-to-uint":
+			if n := elem.Uint(); !target.OverflowUint(n) {
+				target.SetUint(n)
+				continue
+			}
+		case "float-to-int":
+			if f := elem.Float(); f > math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f)) {
+				target.SetInt(int64(f))
+				continue
+			}
+		case "float-to-uint":
+			if f := elem.Float(); f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f)) {
+				target.SetUint(uint64(f))
+				continue
+			}
+		case "int-to-float":
+			target.SetFloat(float64(elem.Int()))
+			continue
+		case "uint-to-float":
+			target.SetFloat(float64(elem.Uint()))
+			continue
+		case "float-to-float":
+			target.SetFloat(elem.Float())
+			continue
+		}
+		return reflect.Value{}, false
+	}
+	return rv, true
 }
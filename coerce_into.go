@@ -0,0 +1,17 @@
+//go:build go1.21
+// +build go1.21
+
+package set
+
+import "github.com/nofeaturesonlybugs/errors"
+
+// CoerceInto coerces src into the value pointed at by dst with the same rules as Value.To(); it is the same as
+// V(dst).To(src) except the destination is type checked at compile time.  An error is returned if dst is nil.
+//	var port uint16
+//	err := set.CoerceInto(&port, "8080")
+func CoerceInto[T any](dst *T, src interface{}) error {
+	if dst == nil {
+		return errors.NilArgument("dst")
+	}
+	return V(dst).To(src)
+}
@@ -0,0 +1,49 @@
+//go:build go1.21
+// +build go1.21
+
+package set_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestCoerceInto(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var port uint16
+		chk.NoError(set.CoerceInto(&port, "8080"))
+		chk.Equal(uint16(8080), port)
+		chk.Error(set.CoerceInto(&port, "http"))
+		chk.Equal(uint16(0), port)
+		chk.Error(set.CoerceInto(&port, -1))
+	}
+	{
+		var ids []int
+		chk.NoError(set.CoerceInto(&ids, []string{"1", "2"}))
+		chk.Equal([]int{1, 2}, ids)
+		chk.Error(set.CoerceInto(&ids, []string{"1", "x"}))
+		chk.Nil(ids)
+	}
+	{
+		type Person struct {
+			Name string
+			Age  int
+		}
+		var p Person
+		chk.NoError(set.CoerceInto(&p, map[string]interface{}{"Name": "bob", "Age": "42"}))
+		chk.Equal(Person{"bob", 42}, p)
+		var ptr *Person
+		chk.NoError(set.CoerceInto(&ptr, Person{"alice", 7}))
+		chk.Equal(&Person{"alice", 7}, ptr)
+		chk.Error(set.CoerceInto(&p, map[string]interface{}{"Age": "old"}))
+	}
+	{
+		var nilInt *int
+		chk.Error(set.CoerceInto(nilInt, 1))
+	}
+}
@@ -1,6 +1,8 @@
 package set
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"testing"
 
@@ -556,6 +558,143 @@ func TestCoerceToString(t *testing.T) {
 	}
 }
 
+func TestCoerce_whitespace(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var err error
+	var b bool
+	var f float64
+	var i int
+	var u uint8
+	for _, v := range []struct {
+		Target interface{}
+		Value  string
+		Expect interface{}
+	}{
+		{&b, " true ", true},
+		{&b, "\t1\n", true},
+		{&f, " 3.14 ", 3.14},
+		{&f, "\n-1.5\t", -1.5},
+		{&i, " 42 ", 42},
+		{&i, " -42", -42},
+		{&i, "3.99 ", 3},
+		{&u, " 42 ", uint8(42)},
+		{&u, "\t7.5\r\n", uint8(7)},
+	} {
+		target := reflect.Indirect(reflect.ValueOf(v.Target))
+		err = coerce(target, reflect.ValueOf(v.Value))
+		chk.NoError(err, "%q", v.Value)
+		chk.Equal(v.Expect, target.Interface(), "%q", v.Value)
+	}
+	// Empty after trimming is an error and leaves the zero value; Options.EmptyAsZero is the lenient opt-in.
+	for _, ptr := range []interface{}{&b, &f, &i, &u} {
+		for _, str := range []string{"", "   ", "\t\n"} {
+			target := reflect.Indirect(reflect.ValueOf(ptr))
+			err = coerce(target, reflect.ValueOf(str))
+			chk.Error(err)
+			chk.True(target.IsZero())
+		}
+	}
+	// Negative numbers into uint are still detected.
+	err = coerce(reflect.Indirect(reflect.ValueOf(&u)), reflect.ValueOf("  -1"))
+	chk.Error(err)
+}
+
+func TestCoerce_sameKindOverflow(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // int64 into int8
+		i8 := int8(5)
+		err := V(&i8).To(int64(300))
+		chk.Error(err)
+		chk.Equal(int8(0), i8)
+		var coerceErr *CoerceError
+		chk.True(errors.As(err, &coerceErr))
+		chk.Contains(err.Error(), "overflows int8")
+		chk.Error(V(&i8).To(int64(-129)))
+		chk.NoError(V(&i8).To(int64(-128)))
+		chk.Equal(int8(-128), i8)
+		chk.NoError(V(&i8).To(int64(127)))
+		chk.Equal(int8(127), i8)
+	}
+	{ // uint into uint8
+		u8 := uint8(5)
+		err := V(&u8).To(uint(256))
+		chk.Error(err)
+		chk.Equal(uint8(0), u8)
+		chk.Contains(err.Error(), "overflows uint8")
+		chk.NoError(V(&u8).To(uint(255)))
+		chk.Equal(uint8(255), u8)
+	}
+	{ // Widening always fits.
+		var i64 int64
+		chk.NoError(V(&i64).To(int16(-300)))
+		chk.Equal(int64(-300), i64)
+	}
+}
+
+func TestCoerce_crossKindOverflow(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // uint into int8
+		i8 := int8(5)
+		err := V(&i8).To(uint(300))
+		chk.Error(err)
+		chk.Equal(int8(0), i8)
+		var coerceErr *CoerceError
+		chk.True(errors.As(err, &coerceErr))
+		chk.Contains(err.Error(), "overflows int8")
+		chk.NoError(V(&i8).To(uint(127)))
+		chk.Equal(int8(127), i8)
+		var i64 int64
+		chk.Error(V(&i64).To(uint64(math.MaxUint64)))
+	}
+	{ // int into uint8
+		u8 := uint8(5)
+		err := V(&u8).To(300)
+		chk.Error(err)
+		chk.Equal(uint8(0), u8)
+		chk.Contains(err.Error(), "overflows uint8")
+		chk.NoError(V(&u8).To(255))
+		chk.Equal(uint8(255), u8)
+	}
+	{ // float into int8
+		i8 := int8(5)
+		err := V(&i8).To(300.5)
+		chk.Error(err)
+		chk.Equal(int8(0), i8)
+		chk.Contains(err.Error(), "overflows int8")
+		chk.NoError(V(&i8).To(-128.9))
+		chk.Equal(int8(-128), i8)
+		var i64 int64
+		chk.Error(V(&i64).To(math.NaN()))
+		chk.Error(V(&i64).To(math.Inf(1)))
+		chk.Error(V(&i64).To(float64(1 << 63)))
+	}
+	{ // float into uint8
+		u8 := uint8(5)
+		err := V(&u8).To(float32(256))
+		chk.Error(err)
+		chk.Equal(uint8(0), u8)
+		chk.Contains(err.Error(), "overflows uint8")
+		chk.NoError(V(&u8).To(255.9))
+		chk.Equal(uint8(255), u8)
+		var u64 uint64
+		chk.Error(V(&u64).To(math.NaN()))
+		chk.Error(V(&u64).To(math.Inf(1)))
+	}
+	{ // Strings parsed as floats.
+		var i8 int8
+		chk.Error(V(&i8).To("300.5"))
+		chk.NoError(V(&i8).To("12.5"))
+		chk.Equal(int8(12), i8)
+		var u8 uint8
+		chk.Error(V(&u8).To("300.5"))
+		chk.NoError(V(&u8).To("12.5"))
+		chk.Equal(uint8(12), u8)
+	}
+}
+
 func TestCoerce_codeCoverage(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -577,3 +716,40 @@ func TestCoerce_codeCoverage(t *testing.T) {
 	_, ok := coerceType(reflect.ValueOf(struct{}{}))
 	chk.Equal(false, ok)
 }
+
+func TestCoerce_boolTruthiness(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var b bool
+	target := reflect.ValueOf(&b).Elem()
+	for _, test := range []struct {
+		Value  interface{}
+		Expect bool
+	}{
+		{int(0), false}, {int(-1), true}, {int8(0), false}, {int8(-128), true}, {int16(0), false}, {int16(2), true},
+		{int32(0), false}, {int32(3), true}, {int64(0), false}, {int64(-4), true},
+		{uint(0), false}, {uint(1), true}, {uint8(0), false}, {uint8(255), true}, {uint16(0), false}, {uint16(2), true},
+		{uint32(0), false}, {uint32(3), true}, {uint64(0), false}, {uint64(math.MaxUint64), true},
+		{float32(0), false}, {float32(0.1), true}, {float64(0), false}, {float64(-0.1), true},
+		{math.Copysign(0, -1), false}, {math.NaN(), true}, {math.Inf(-1), true},
+	} {
+		b = !test.Expect
+		chk.NoError(coerce(target, reflect.ValueOf(test.Value)), "%T(%v)", test.Value, test.Value)
+		chk.Equal(test.Expect, b, "%T(%v)", test.Value, test.Value)
+	}
+	// Symmetric: bool to numeric and back again.
+	for _, ptr := range []interface{}{
+		new(int), new(int8), new(int16), new(int32), new(int64),
+		new(uint), new(uint8), new(uint16), new(uint32), new(uint64),
+		new(float32), new(float64),
+	} {
+		number := reflect.ValueOf(ptr).Elem()
+		for _, value := range []bool{true, false} {
+			chk.NoError(coerce(number, reflect.ValueOf(value)))
+			chk.Equal(value, numericToBool(number), "%v", number.Type())
+			b = !value
+			chk.NoError(coerce(target, number))
+			chk.Equal(value, b, "%v", number.Type())
+		}
+	}
+}
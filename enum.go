@@ -0,0 +1,206 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+// enums is the registry of enum types and their allowed values; each entry is a map[interface{}]struct{}.
+var enums = &sync.Map{}
+
+// enumCount is the number of registered enum types; when it is zero checkEnum returns immediately.
+var enumCount int32
+
+// RegisterEnum registers the allowed values for the type of sample; if sample is a pointer the type at the end
+// of the pointer chain is registered.  Calling RegisterEnum again for the same type replaces its allowed values
+// and calling it with no allowed values removes the registration.
+//
+// Each allowed value is coerced into the type of sample; RegisterEnum panics if an allowed value can not be coerced.
+//
+// Once registered Value.To() returns an error for any value of the registered type that is not one of the
+// allowed values:
+//	type Color string
+//	const (
+//		Red  Color = "red"
+//		Blue Color = "blue"
+//	)
+//	set.RegisterEnum(Color(""), Red, Blue)
+//	var c Color
+//	set.V(&c).To("red")	// c is Red
+//	set.V(&c).To("green")	// returns an error and c is the zero value
+func RegisterEnum(sample interface{}, allowed ...interface{}) {
+	T := TypeCache.Stat(sample).Type
+	if T == nil {
+		return
+	}
+	if len(allowed) == 0 {
+		if _, ok := enums.Load(T); ok {
+			enums.Delete(T)
+			atomic.AddInt32(&enumCount, -1)
+		}
+		return
+	}
+	members := make(map[interface{}]struct{}, len(allowed))
+	for _, value := range allowed {
+		member := reflect.New(T).Elem()
+		if err := coerceMember(member, reflect.ValueOf(value)); err != nil {
+			panic(fmt.Sprintf("RegisterEnum %v with allowed value %v: %v", T, value, err.Error()))
+		}
+		members[member.Interface()] = struct{}{}
+	}
+	if _, loaded := enums.LoadOrStore(T, members); loaded {
+		enums.Store(T, members)
+	} else {
+		atomic.AddInt32(&enumCount, 1)
+	}
+}
+
+// coerceMember coerces value into member for RegisterEnum.
+func coerceMember(member reflect.Value, value reflect.Value) error {
+	for ; value.Kind() == reflect.Ptr; value = value.Elem() {
+		if value.IsNil() {
+			return errors.Errorf("nil pointer")
+		}
+	}
+	if !value.IsValid() {
+		return errors.Errorf("nil value")
+	} else if value.Type().AssignableTo(member.Type()) {
+		member.Set(value)
+		return nil
+	}
+	return coerceScalar(member, value)
+}
+
+// isEnum returns true if T is a registered enum type.
+func isEnum(T reflect.Type) bool {
+	if atomic.LoadInt32(&enumCount) == 0 {
+		return false
+	}
+	_, ok := enums.Load(T)
+	return ok
+}
+
+// checkEnum returns an error if target is a registered enum type and its value is not an allowed value;
+// target is set to its zero value when an error is returned.
+func checkEnum(target reflect.Value) error {
+	if atomic.LoadInt32(&enumCount) == 0 {
+		return nil
+	}
+	members, ok := enums.Load(target.Type())
+	if !ok {
+		return nil
+	}
+	value := target.Interface()
+	if _, ok = members.(map[interface{}]struct{})[value]; !ok {
+		target.Set(reflect.Zero(target.Type()))
+		return errors.Errorf("Value %v is not an allowed value for enum %v", value, target.Type())
+	}
+	return nil
+}
+
+// enumNames is the registry of named integer types and their labels; each entry is an enumLabels.
+var enumNames = &sync.Map{}
+
+// enumNameCount is the number of types registered with RegisterEnumNames; when it is zero coerceEnumName
+// returns immediately.
+var enumNameCount int32
+
+// enumLabels maps labels to values and values to labels for RegisterEnumNames.
+type enumLabels struct {
+	values map[string]int64
+	labels map[int64]string
+}
+
+// RegisterEnumNames registers labels for the named integer type T so strings can be coerced into T by label
+// and T can be coerced into strings as its label.  Calling RegisterEnumNames again for the same type replaces its
+// labels and calling it with an empty mapping removes the registration.  RegisterEnumNames panics if T is not
+// an integer type.
+//
+// When the source is a string that is not a label it is coerced as a number; if that also fails an error naming
+// the unknown label is returned.  Values of T without a label are coerced into strings as numbers.
+//	type Status int
+//	const (
+//		Inactive Status = iota
+//		Active
+//	)
+//	set.RegisterEnumNames(reflect.TypeOf(Status(0)), map[string]int64{"inactive": 0, "active": 1})
+//	var s Status
+//	set.V(&s).To("active")	// s is Active
+//	var str string
+//	set.V(&str).To(Active)	// str is "active"
+//
+// See RegisterEnum() to restrict the values allowed for a type.
+func RegisterEnumNames(T reflect.Type, mapping map[string]int64) {
+	if T == nil {
+		return
+	} else if numericKind(T.Kind()) != "int" && numericKind(T.Kind()) != "uint" {
+		panic(fmt.Sprintf("RegisterEnumNames %v is not an integer type", T))
+	}
+	if len(mapping) == 0 {
+		if _, ok := enumNames.Load(T); ok {
+			enumNames.Delete(T)
+			atomic.AddInt32(&enumNameCount, -1)
+		}
+		return
+	}
+	labels := enumLabels{values: make(map[string]int64, len(mapping)), labels: make(map[int64]string, len(mapping))}
+	for label, value := range mapping {
+		labels.values[label] = value
+		if existing, ok := labels.labels[value]; !ok || label < existing {
+			labels.labels[value] = label // The smallest label wins when labels share a value.
+		}
+	}
+	if _, loaded := enumNames.LoadOrStore(T, labels); loaded {
+		enumNames.Store(T, labels)
+	} else {
+		atomic.AddInt32(&enumNameCount, 1)
+	}
+}
+
+// coerceEnumName coerces a string label into target when target's type was registered with RegisterEnumNames
+// or coerces a registered value into its label when target is a string.  The first return value is false if
+// neither type is registered.
+func coerceEnumName(target reflect.Value, value reflect.Value) (bool, error) {
+	if atomic.LoadInt32(&enumNameCount) == 0 {
+		return false, nil
+	}
+	if value.Kind() == reflect.String {
+		registered, ok := enumNames.Load(target.Type())
+		if !ok {
+			return false, nil
+		}
+		str := strings.TrimSpace(value.String())
+		if number, ok := registered.(enumLabels).values[str]; ok {
+			if numericKind(target.Kind()) == "int" {
+				target.SetInt(number)
+			} else {
+				target.SetUint(uint64(number))
+			}
+			return true, nil
+		} else if err := coerceScalar(target, value); err != nil {
+			return true, errors.Errorf("Unknown label %v for enum %v", value.String(), target.Type())
+		}
+		return true, nil
+	} else if target.Kind() == reflect.String {
+		registered, ok := enumNames.Load(value.Type())
+		if !ok {
+			return false, nil
+		}
+		var number int64
+		if numericKind(value.Kind()) == "int" {
+			number = value.Int()
+		} else {
+			number = int64(value.Uint())
+		}
+		if label, ok := registered.(enumLabels).labels[number]; ok {
+			target.SetString(label)
+			return true, nil
+		}
+	}
+	return false, nil
+}
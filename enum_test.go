@@ -0,0 +1,121 @@
+package set_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestRegisterEnum(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Color string
+	const (
+		Red  Color = "red"
+		Blue Color = "blue"
+	)
+	type Level int
+	set.RegisterEnum(Color(""), Red, Blue)
+	set.RegisterEnum(new(Level), 1, "2", 3.0)
+	defer set.RegisterEnum(Color(""))
+	defer set.RegisterEnum(Level(0))
+	{
+		var c Color
+		v := set.V(&c)
+		chk.NoError(v.To("red"))
+		chk.Equal(Red, c)
+		chk.NoError(v.To(Blue))
+		chk.Equal(Blue, c)
+		red := Red
+		chk.NoError(v.To(&red))
+		chk.Equal(Red, c)
+		chk.Error(v.To("green"))
+		chk.Equal(Color(""), c)
+		c = Red
+		chk.Error(v.To(Color("green")))
+		chk.Equal(Color(""), c)
+	}
+	{
+		var l Level
+		v := set.V(&l)
+		chk.NoError(v.To("2"))
+		chk.Equal(Level(2), l)
+		chk.NoError(v.To(3))
+		chk.Equal(Level(3), l)
+		chk.Error(v.To(4))
+		chk.Equal(Level(0), l)
+	}
+	{ // Enums within slices.
+		var c []Color
+		chk.NoError(set.V(&c).To([]string{"red", "blue"}))
+		chk.Equal([]Color{Red, Blue}, c)
+		chk.Error(set.V(&c).To([]string{"red", "green"}))
+		chk.Nil(c)
+	}
+	{ // Invalid allowed values panic.
+		chk.Panics(func() { set.RegisterEnum(Level(0), "one") })
+	}
+	{ // Removing the registration allows any value.
+		set.RegisterEnum(Color(""))
+		var c Color
+		chk.NoError(set.V(&c).To("green"))
+		chk.Equal(Color("green"), c)
+	}
+}
+
+func TestRegisterEnumNames(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Status int
+	type Priority uint8
+	const (
+		Inactive Status = iota
+		Active
+		Banned
+	)
+	set.RegisterEnumNames(reflect.TypeOf(Status(0)), map[string]int64{"inactive": 0, "active": 1, "banned": 2})
+	set.RegisterEnumNames(reflect.TypeOf(Priority(0)), map[string]int64{"low": 1, "high": 9})
+	defer set.RegisterEnumNames(reflect.TypeOf(Status(0)), nil)
+	defer set.RegisterEnumNames(reflect.TypeOf(Priority(0)), nil)
+	{ // Label to value.
+		var s Status
+		chk.NoError(set.V(&s).To("active"))
+		chk.Equal(Active, s)
+		chk.NoError(set.V(&s).To("2"))
+		chk.Equal(Banned, s)
+		err := set.V(&s).To("deleted")
+		chk.Error(err)
+		chk.Contains(err.Error(), "Unknown label deleted")
+		chk.Equal(Inactive, s)
+		var p Priority
+		chk.NoError(set.V(&p).To("high"))
+		chk.Equal(Priority(9), p)
+	}
+	{ // Value to label.
+		var str string
+		chk.NoError(set.V(&str).To(Banned))
+		chk.Equal("banned", str)
+		chk.NoError(set.V(&str).To(Priority(1)))
+		chk.Equal("low", str)
+		chk.NoError(set.V(&str).To(Status(7))) // No label.
+		chk.Equal("7", str)
+	}
+	{ // Fill
+		type T struct {
+			Status Status
+			Label  string
+		}
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Status": "banned", "Label": Active})))
+		chk.Equal(T{Banned, "active"}, dest)
+	}
+	{
+		chk.Panics(func() { set.RegisterEnumNames(reflect.TypeOf(""), map[string]int64{"a": 1}) })
+		set.RegisterEnumNames(reflect.TypeOf(Status(0)), nil)
+		var s Status
+		chk.Error(set.V(&s).To("active"))
+	}
+}
@@ -0,0 +1,92 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CoerceError is returned when a value can not be coerced into the destination type.
+//
+// Value.To() returns a *CoerceError as-is so it can be found with errors.As(); methods such as Fill() return
+// errors from github.com/nofeaturesonlybugs/errors and the *CoerceError is available via errors.Original().
+type CoerceError struct {
+	// Src and Dst are the types of the source value and the destination.
+	Src, Dst reflect.Type
+	// Value is the source value.
+	Value interface{}
+	// Err is the reason the coercion failed.
+	Err error
+}
+
+// newCoerceError returns a *CoerceError describing the failure to coerce value into target; nil is returned
+// if err is nil.
+func newCoerceError(target reflect.Value, value reflect.Value, err error) error {
+	if err == nil {
+		return nil
+	}
+	rv := &CoerceError{Src: value.Type(), Dst: target.Type(), Err: err}
+	if value.CanInterface() {
+		rv.Value = value.Interface()
+	}
+	return rv
+}
+
+// Error returns the error string.
+func (me *CoerceError) Error() string {
+	return fmt.Sprintf("Coercing %v into %v: %v", me.Src, me.Dst, me.Err.Error())
+}
+
+// Unwrap returns the reason the coercion failed.
+func (me *CoerceError) Unwrap() error {
+	return me.Err
+}
+
+// ElemError describes an element of a source slice that could not be coerced.
+type ElemError struct {
+	// Index is the index of the element in the source slice.
+	Index int
+	// Err is the error returned while coercing the element.
+	Err error
+}
+
+// Error returns the error string.
+func (me ElemError) Error() string {
+	return fmt.Sprintf("Index %v: %v", me.Index, me.Err.Error())
+}
+
+// Unwrap returns the error for the element.
+func (me ElemError) Unwrap() error {
+	return me.Err
+}
+
+// ElemErrors is a collection of ElemError; it is returned when an operation was only partially completed
+// because some elements were skipped.
+type ElemErrors []ElemError
+
+// Error returns the error string.
+func (me ElemErrors) Error() string {
+	parts := make([]string, len(me))
+	for k, err := range me {
+		parts[k] = err.Error()
+	}
+	return fmt.Sprintf("%v element(s) skipped: %v", len(me), strings.Join(parts, "; "))
+}
+
+// ContextError is returned by a Value created with WithContext(); Context is the prefix given to WithContext().
+type ContextError struct {
+	// Context is the prefix of the error string.
+	Context string
+	// Err is the error being prefixed.
+	Err error
+}
+
+// Error returns the error string.
+func (me *ContextError) Error() string {
+	return fmt.Sprintf("%v: %v", me.Context, me.Err.Error())
+}
+
+// Unwrap returns the error being prefixed.
+func (me *ContextError) Unwrap() error {
+	return me.Err
+}
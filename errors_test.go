@@ -0,0 +1,133 @@
+package set_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	stderrors "errors"
+
+	"github.com/nofeaturesonlybugs/errors"
+	"github.com/nofeaturesonlybugs/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerceError(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // Scalar
+		var i int
+		err := set.V(&i).To("Hello")
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		chk.Equal(reflect.TypeOf(""), coerceErr.Src)
+		chk.Equal(reflect.TypeOf(0), coerceErr.Dst)
+		chk.Equal("Hello", coerceErr.Value)
+		chk.Error(coerceErr.Unwrap())
+		chk.Contains(err.Error(), "Coercing string into int")
+	}
+	{ // Unsupported
+		var f float64
+		err := set.V(&f).To(map[string]int{})
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		chk.Equal(reflect.TypeOf(map[string]int{}), coerceErr.Src)
+		chk.Equal(reflect.TypeOf(0.0), coerceErr.Dst)
+	}
+	{ // Slice elements unwrap to the element's error.
+		var b []bool
+		err := set.V(&b).To([]string{"true", "maybe"})
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		chk.Equal("maybe", coerceErr.Value)
+		chk.Equal(reflect.TypeOf(false), coerceErr.Dst)
+	}
+	{ // Fill
+		type T struct {
+			Age int
+		}
+		var s T
+		err := set.V(&s).Fill(set.MapGetter(map[string]interface{}{"Age": "old"}))
+		chk.Error(err)
+		coerceErr, ok := errors.Original(err).(*set.CoerceError)
+		chk.True(ok)
+		chk.Equal("old", coerceErr.Value)
+	}
+}
+
+func TestContextError(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // To
+		var i int
+		err := set.V(&i).WithContext("config.port").To("http")
+		chk.Error(err)
+		chk.True(strings.HasPrefix(err.Error(), "config.port: Coercing string into int"))
+		var contextErr *set.ContextError
+		chk.True(stderrors.As(err, &contextErr))
+		chk.Equal("config.port", contextErr.Context)
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		chk.Equal("http", coerceErr.Value)
+		chk.NoError(set.V(&i).WithContext("config.port").To("8080"))
+		chk.Equal(8080, i)
+	}
+	{ // Prefixes are joined; the original Value is unaltered.
+		var i int
+		v := set.V(&i)
+		err := v.WithContext("config").WithContext("port").To("http")
+		chk.True(strings.HasPrefix(err.Error(), "config: port: Coercing"))
+		err = v.WithContext("").To("http")
+		_, ok := err.(*set.ContextError)
+		chk.False(ok)
+		_, ok = v.To("http").(*set.ContextError)
+		chk.False(ok)
+	}
+	{ // Append and ToAppend
+		var s []int
+		v := set.V(&s).WithContext("ids")
+		err := v.Append(1, "x")
+		chk.Error(err)
+		chk.True(strings.HasPrefix(err.Error(), "ids: "))
+		err = v.ToAppend([]string{"2", "y"})
+		chk.Error(err)
+		chk.True(strings.HasPrefix(err.Error(), "ids: "))
+		chk.Nil(s)
+		chk.NoError(v.Append(1, "2"))
+		chk.Equal([]int{1, 2}, s)
+	}
+	{ // Fill methods
+		type T struct {
+			Age int `json:"age" db:"age"`
+		}
+		var s T
+		v := set.V(&s).WithContext("row 3")
+		err := v.Fill(set.MapGetter(map[string]interface{}{"Age": "old"}))
+		chk.True(strings.HasPrefix(err.Error(), "row 3: "))
+		var contextErr *set.ContextError
+		chk.True(stderrors.As(err, &contextErr))
+		_, ok := errors.Original(contextErr.Err).(*set.CoerceError)
+		chk.True(ok)
+		err = v.FillByTag("db", set.MapGetter(map[string]interface{}{"age": "old"}))
+		chk.True(strings.HasPrefix(err.Error(), "row 3: "))
+		err = v.FillByTags([]string{"db"}, set.MapGetter(map[string]interface{}{"age": "old"}))
+		chk.True(strings.HasPrefix(err.Error(), "row 3: "))
+		err = v.FillJSON(set.MapGetter(map[string]interface{}{"age": "old"}))
+		chk.True(strings.HasPrefix(err.Error(), "row 3: "))
+		err = v.FillInsensitive(set.MapGetter(map[string]interface{}{"AGE": "old"}))
+		chk.True(strings.HasPrefix(err.Error(), "row 3: "))
+		var all []T
+		err = set.V(&all).WithContext("rows").FillAll([]set.Getter{set.MapGetter(map[string]interface{}{"Age": "old"})})
+		chk.True(strings.HasPrefix(err.Error(), "rows: FillAll row 0"))
+		err = set.V(&all).WithContext("stream").FillStream(json.NewDecoder(strings.NewReader(`{"Age": "old"}`)))
+		chk.True(strings.HasPrefix(err.Error(), "stream: FillStream record 0"))
+		err = set.V(&all).WithContext("stream").FillStream(json.NewDecoder(strings.NewReader(`{`)))
+		chk.True(strings.HasPrefix(err.Error(), "stream: FillStream record 0"))
+		chk.True(strings.HasPrefix(set.V(all).WithContext("stream").FillStream(nil).Error(), "stream: "))
+	}
+	{ // Nil
+		var v *set.Value
+		chk.Nil(v.WithContext("x"))
+	}
+}
@@ -113,3 +113,26 @@ func ExampleValue_To_addressability() {
 	// 8. It works with strings too.
 	// 9. String pointers are no different.
 }
+
+func ExampleValue_FillAll() {
+	type Row struct {
+		ID   int
+		Name string
+	}
+	getters := []set.Getter{
+		set.MapGetter(map[string]interface{}{"ID": 1, "Name": "Alice"}),
+		set.MapGetter(map[string]interface{}{"ID": "2", "Name": "Bob"}),
+	}
+	rows := []Row{{ID: 100, Name: "Replaced"}}
+	if err := set.V(&rows).FillAll(getters); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(rows)
+	//
+	getters = append(getters, set.MapGetter(map[string]interface{}{"ID": "three"}))
+	if err := set.V(&rows).FillAll(getters); err != nil {
+		fmt.Println("Error filling row 2; rows is nil:", rows == nil)
+	}
+	// Output: [{1 Alice} {2 Bob}]
+	// Error filling row 2; rows is nil: true
+}
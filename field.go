@@ -9,4 +9,139 @@ type Field struct {
 	Value    *Value
 	Field    reflect.StructField
 	TagValue string
+	// TagValues is set by Value.FieldsByTags() and maps each of its struct-tags present on the field to the
+	// tag's value.
+	TagValues map[string]string
+}
+
+// FlatField is a field returned by Value.DeepFields(); Path is the names of the fields leading to the field
+// joined with a period, e.g. "Address.City".
+type FlatField struct {
+	Path  string
+	Field reflect.StructField
+	Value *Value
+}
+
+// flattenFields returns the fields of the struct type T with the fields of embedded structs promoted
+// according to the Go language rules; the Index of each returned field is the full index path from T.
+// Fields are returned depth-first in declaration order.
+func flattenFields(T reflect.Type) []reflect.StructField {
+	type candidate struct {
+		field reflect.StructField
+		depth int
+	}
+	var candidates []candidate
+	depths := map[string][]int{} // depths[name] is the list of depths the name was seen
+	//
+	var scan func(T reflect.Type, index []int, depth int, visited map[reflect.Type]bool)
+	scan = func(T reflect.Type, index []int, depth int, visited map[reflect.Type]bool) {
+		visited[T] = true
+		defer delete(visited, T)
+		for k, size := 0, T.NumField(); k < size; k++ {
+			field := T.Field(k)
+			field.Index = append(append([]int{}, index...), k)
+			depths[field.Name] = append(depths[field.Name], depth)
+			if embedded, ok := embeddedStruct(field); ok {
+				if !visited[embedded] {
+					scan(embedded, field.Index, depth+1, visited)
+				}
+				continue
+			}
+			candidates = append(candidates, candidate{field: field, depth: depth})
+		}
+	}
+	scan(T, nil, 0, map[reflect.Type]bool{})
+	//
+	var rv []reflect.StructField
+	for _, c := range candidates {
+		count := 0
+		for _, depth := range depths[c.field.Name] {
+			if depth < c.depth {
+				count = -1 // Shadowed by a shallower field.
+				break
+			} else if depth == c.depth {
+				count++
+			}
+		}
+		if count == 1 {
+			rv = append(rv, c.field)
+		}
+	}
+	return rv
+}
+
+// embeddedStruct returns the struct type of field when field is an embedded struct or an embedded pointer
+// to a struct that can be traversed; unexported embedded pointers are not traversed because they can not
+// be instantiated and atomic types are not traversed because they are leaves.
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous {
+		return nil, false
+	}
+	T := field.Type
+	if T.Kind() == reflect.Ptr {
+		if field.PkgPath != "" {
+			return nil, false
+		}
+		T = T.Elem()
+	}
+	if T.Kind() != reflect.Struct || isAtomic(T) {
+		return nil, false
+	}
+	return T, true
+}
+
+// fieldByIndexPath returns the field of the struct v at the full index path; pointers to structs along the
+// path are instantiated if they are nil and settable.  The final field is returned as-is, i.e. if it is a
+// pointer it is not followed.  The second return value is false if the path can not be traversed.
+func fieldByIndexPath(v reflect.Value, index []int) (reflect.Value, bool) {
+	for k, size := 0, len(index); k < size; k++ {
+		if k > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return reflect.Value{}, false
+					}
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		v = v.Field(index[k])
+	}
+	return v, true
+}
+
+// promotedFields returns fields followed by the fields of v promoted from embedded structs, as described by
+// flattenFields, for which getter returns a non-nil value; name returns the name passed to getter for a promoted
+// field or "" if the field should not be promoted.
+func promotedFields(v *Value, fields []Field, getter Getter, name func(reflect.StructField) string) []Field {
+	hasEmbedded := false
+	for _, f := range fields {
+		if _, ok := embeddedStruct(f.Field); ok {
+			hasEmbedded = true
+			break
+		}
+	}
+	if !hasEmbedded {
+		return fields
+	}
+	for _, f := range flattenFields(v.Type) {
+		if len(f.Index) == 1 {
+			continue
+		} else if key := name(f); key == "" || getter.Get(key) == nil {
+			continue
+		}
+		if fv, ok := fieldByIndexPath(v.WriteValue, f.Index); ok {
+			fields = append(fields, Field{Value: v.v(fv), Field: f})
+		}
+	}
+	return fields
+}
+
+// fieldName returns the name of the field; it is the name passed to promotedFields by Fill().
+func fieldName(f reflect.StructField) string {
+	return f.Name
 }
@@ -0,0 +1,211 @@
+package set
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+// FillOption configures the behavior of Value.Fill(), Value.FillByTag(), and Value.FillByTags().
+type FillOption func(*fillConfig)
+
+// fillConfig is the configuration created from a list of FillOption.
+type fillConfig struct {
+	// depth is the current level of nesting; the top level struct is depth 0.
+	depth int
+	// maxDepth is the maximum level of nesting; 0 is unlimited.
+	maxDepth int
+	// scalarKeys are the keys queried on a sub-Getter returned for a field that can not be sub-filled.
+	scalarKeys []string
+	// delimiter splits strings returned for slice fields when it is not empty.
+	delimiter string
+	// skipUnchanged is set by SkipUnchanged() and changed is its callback; path is prepended to the field names
+	// passed to changed.
+	skipUnchanged bool
+	changed       func(field string)
+	path          string
+}
+
+// newFillConfig returns a *fillConfig with opts applied.
+func newFillConfig(opts []FillOption) *fillConfig {
+	rv := &fillConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(rv)
+		}
+	}
+	return rv
+}
+
+// nested returns the configuration for a nested struct or slice of structs found while filling field;
+// an error is returned if descending would exceed the maximum depth.
+func (me *fillConfig) nested(field string) (*fillConfig, error) {
+	if me.maxDepth > 0 && me.depth >= me.maxDepth {
+		return nil, errors.Errorf("Field %v exceeds the maximum fill depth of %v", field, me.maxDepth)
+	}
+	rv := *me
+	rv.depth++
+	rv.path = me.path + field + "."
+	return &rv, nil
+}
+
+// MaxDepth limits the number of levels of nested structs, or slices of structs, that Fill will descend
+// into; an error is returned if the Getter would cause Fill to descend further.  Use this when filling
+// from untrusted sources such as decoded JSON.
+//
+// The top level struct is depth 0 so MaxDepth(1) allows its fields to be filled from sub-Getters but
+// not the fields of those nested structs.  A value less than or equal to zero is unlimited, which is
+// the default.
+func MaxDepth(n int) FillOption {
+	return func(cfg *fillConfig) {
+		if n < 0 {
+			n = 0
+		}
+		cfg.maxDepth = n
+	}
+}
+
+// ScalarKeys allows Fill to populate a field that can not be sub-filled, such as an int or string, when the
+// Getter returns a sub-Getter for that field.  Each key is passed to the sub-Getter in order and the first
+// value that is not nil is assigned to the field with Value.To().  If no keys are given the conventional
+// keys "value" and "" are used:
+//	// The data source wraps scalars in objects.
+//	data := map[string]interface{}{
+//		"Name": map[string]interface{}{"value": "Bob"},
+//	}
+//	err := set.V(&t).Fill(set.MapGetter(data), set.ScalarKeys()) // t.Name is "Bob"
+//
+// Without this option, or when none of the keys return a value, Fill returns an error.
+func ScalarKeys(keys ...string) FillOption {
+	if len(keys) == 0 {
+		keys = []string{"value", ""}
+	}
+	return func(cfg *fillConfig) {
+		cfg.scalarKeys = append([]string(nil), keys...)
+	}
+}
+
+// scalar returns the first value returned by getter for the configured scalar keys; the second return
+// value is false if no value was found.
+func (me *fillConfig) scalar(getter Getter) (interface{}, bool) {
+	for _, key := range me.scalarKeys {
+		if value := getter.Get(key); value != nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// SplitStrings causes Fill to split a string returned by the Getter for a slice field on delimiter before
+// coercing the parts into the slice; surrounding whitespace is removed from each part and an empty string
+// becomes an empty slice.  The delimiter defaults to a comma:
+//	data := map[string]interface{}{
+//		"Tags": "a, b, c",
+//		"IDs":  "1,2,3",
+//	}
+//	err := set.V(&t).Fill(set.MapGetter(data), set.SplitStrings("")) // t.Tags is []string{"a", "b", "c"}
+//
+// Splitting can also be enabled for individual fields with the csv flag, or a csv=delimiter option, in the
+// field's set struct-tag; the struct-tag is honored by every fill method regardless of this option:
+//	type T struct {
+//		Tags  []string `set:",csv"`
+//		Paths []string `set:",csv=:"`
+//	}
+func SplitStrings(delimiter string) FillOption {
+	if delimiter == "" {
+		delimiter = ","
+	}
+	return func(cfg *fillConfig) {
+		cfg.delimiter = delimiter
+	}
+}
+
+// split returns value split into a []string if field is a slice, value is a string, and splitting is enabled
+// for field by SplitStrings() or its set struct-tag; otherwise value is returned unchanged.
+func (me *fillConfig) split(field Field, value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok || !field.Value.IsSlice {
+		return value
+	}
+	delimiter := me.delimiter
+	if tag, ok := field.Field.Tag.Lookup("set"); ok {
+		options := ParseTag(tag)
+		if custom, ok := options.Option("csv"); ok && custom != "" {
+			delimiter = custom
+		} else if options.Has("csv") {
+			delimiter = ","
+		}
+	}
+	if delimiter == "" {
+		return value
+	} else if strings.TrimSpace(str) == "" {
+		return []string{}
+	}
+	parts := strings.Split(str, delimiter)
+	for k := range parts {
+		parts[k] = strings.TrimSpace(parts[k])
+	}
+	return parts
+}
+
+// SkipUnchanged causes Fill to leave a field as it was when the value from the Getter, once coerced, is equal to
+// the field's current value according to reflect.DeepEqual(); this is useful when change detection, such as the
+// dirty fields tracked by an ORM, should not see fields whose values are the same.  If changed is not nil it is
+// called with the name of each field whose value did change; the names of nested fields are joined with a
+// period:
+//	var changes []string
+//	err := set.V(&user).Fill(getter, set.SkipUnchanged(func(field string) {
+//		changes = append(changes, field) // e.g. "Email" and "Address.City"
+//	}))
+//
+// Nested structs filled from a sub-Getter are compared field by field; all other fields, including slices of
+// structs and maps, are compared and reported as a whole.
+func SkipUnchanged(changed func(field string)) FillOption {
+	return func(cfg *fillConfig) {
+		cfg.skipUnchanged = true
+		cfg.changed = changed
+	}
+}
+
+// fillSnapshot is the value of a field before it is filled when SkipUnchanged() is in effect.
+type fillSnapshot struct {
+	// previous is a shallow copy that is restored if the field does not change; deep is compared to the
+	// field after it is filled.
+	previous, deep reflect.Value
+}
+
+// snapshot returns the value of field before it is filled with value; the second return value is false if the
+// field is not compared as a whole, which includes structs whose own fields are filled from a sub-Getter.
+func (me *fillConfig) snapshot(field Field, value interface{}) (fillSnapshot, bool) {
+	top := field.Value.TopValue
+	if !me.skipUnchanged || !top.CanSet() || !top.CanInterface() {
+		return fillSnapshot{}, false
+	}
+	switch value.(type) {
+	case Getter, []Getter:
+		if _, assembled := assemblerFor(field.Value.Type); field.Value.IsStruct && !isAtomic(field.Value.Type) && !assembled {
+			return fillSnapshot{}, false
+		}
+	}
+	previous := reflect.New(top.Type()).Elem()
+	previous.Set(top)
+	return fillSnapshot{previous: previous, deep: deepCopy(previous, map[uintptr]reflect.Value{})}, true
+}
+
+// whole returns a copy of the configuration for filling a field that is compared as a whole.
+func (me *fillConfig) whole() *fillConfig {
+	rv := *me
+	rv.skipUnchanged, rv.changed = false, nil
+	return &rv
+}
+
+// compare restores field to its snapshot if its new value is equal; otherwise the field is reported as changed.
+func (me *fillConfig) compare(field Field, before fillSnapshot) {
+	top := field.Value.TopValue
+	if reflect.DeepEqual(before.deep.Interface(), top.Interface()) {
+		top.Set(before.previous)
+	} else if me.changed != nil {
+		me.changed(me.path + field.Field.Name)
+	}
+}
@@ -0,0 +1,251 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestFillOption_maxDepth(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Node struct {
+		Name     string `json:"name"`
+		Child    *Node  `json:"child"`
+		Children []Node `json:"children"`
+	}
+	node := func(name string, child map[string]interface{}) map[string]interface{} {
+		rv := map[string]interface{}{"Name": name, "name": name}
+		if child != nil {
+			rv["Child"], rv["child"] = child, child
+		}
+		return rv
+	}
+	data := node("root", node("one", node("two", nil)))
+	{ // Unlimited by default.
+		var n Node
+		chk.NoError(set.V(&n).Fill(set.MapGetter(data)))
+		chk.Equal("two", n.Child.Child.Name)
+		n = Node{}
+		chk.NoError(set.V(&n).Fill(set.MapGetter(data), set.MaxDepth(0)))
+		chk.Equal("two", n.Child.Child.Name)
+	}
+	{ // Enough depth.
+		var n Node
+		chk.NoError(set.V(&n).Fill(set.MapGetter(data), set.MaxDepth(2)))
+		chk.Equal("two", n.Child.Child.Name)
+	}
+	{ // Exceeded.
+		var n Node
+		err := set.V(&n).Fill(set.MapGetter(data), set.MaxDepth(1))
+		chk.Error(err)
+		chk.Contains(err.Error(), "maximum fill depth of 1")
+		n = Node{}
+		chk.Error(set.V(&n).FillByTag("json", set.MapGetter(data), set.MaxDepth(1)))
+		n = Node{}
+		chk.Error(set.V(&n).FillByTags([]string{"json"}, set.MapGetter(data), set.MaxDepth(1)))
+		n = Node{}
+		chk.NoError(set.V(&n).FillByTags([]string{"json"}, set.MapGetter(data), set.MaxDepth(2)))
+	}
+	{ // Slices of structs count as a level.
+		slices := map[string]interface{}{
+			"Name": "root",
+			"Children": []map[string]interface{}{
+				{"Name": "a"},
+				{"Name": "b", "Children": []map[string]interface{}{{"Name": "c"}}},
+			},
+		}
+		var n Node
+		chk.NoError(set.V(&n).Fill(set.MapGetter(slices), set.MaxDepth(2)))
+		chk.Equal("c", n.Children[1].Children[0].Name)
+		n = Node{}
+		chk.Error(set.V(&n).Fill(set.MapGetter(slices), set.MaxDepth(1)))
+	}
+}
+
+func TestFillOption_scalarKeys(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Inner struct {
+		Value string
+	}
+	type T struct {
+		Name  string
+		Age   int
+		Tags  []string
+		Inner Inner
+	}
+	data := map[string]interface{}{
+		"Name":  map[string]interface{}{"value": "Bob"},
+		"Age":   map[string]interface{}{"": "42"},
+		"Tags":  map[string]interface{}{"value": []string{"a", "b"}},
+		"Inner": map[string]interface{}{"Value": "inner"},
+	}
+	{ // Off by default.
+		var t T
+		err := set.V(&t).Fill(set.MapGetter(data))
+		chk.Error(err)
+		chk.Contains(err.Error(), "not fillable")
+	}
+	{ // Conventional keys.
+		var t T
+		chk.NoError(set.V(&t).Fill(set.MapGetter(data), set.ScalarKeys()))
+		chk.Equal("Bob", t.Name)
+		chk.Equal(42, t.Age)
+		chk.Equal([]string{"a", "b"}, t.Tags)
+		chk.Equal("inner", t.Inner.Value) // Structs are still sub-filled.
+	}
+	{ // Custom keys.
+		custom := map[string]interface{}{
+			"Name": map[string]interface{}{"v": "Sue", "value": "ignored"},
+			"Age":  map[string]interface{}{"value": 30},
+		}
+		var t T
+		chk.NoError(set.V(&t).Fill(set.MapGetter(custom), set.ScalarKeys("v", "value")))
+		chk.Equal("Sue", t.Name)
+		chk.Equal(30, t.Age)
+	}
+	{ // No key matched.
+		missing := map[string]interface{}{
+			"Name": map[string]interface{}{"other": "Bob"},
+		}
+		var t T
+		chk.Error(set.V(&t).Fill(set.MapGetter(missing), set.ScalarKeys()))
+	}
+}
+
+func TestFillOption_splitStrings(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Tags  []string
+		IDs   []int
+		Name  string
+		Empty []string
+	}
+	data := map[string]interface{}{
+		"Tags":  "a, b ,c",
+		"IDs":   "1,2,3",
+		"Name":  "x,y",
+		"Empty": "",
+	}
+	{
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(data), set.SplitStrings("")))
+		chk.Equal([]string{"a", "b", "c"}, dest.Tags)
+		chk.Equal([]int{1, 2, 3}, dest.IDs)
+		chk.Equal("x,y", dest.Name) // Not a slice.
+		chk.Len(dest.Empty, 0)
+	}
+	{ // Off by default.
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Tags": "a, b ,c"})))
+		chk.Equal([]string{"a, b ,c"}, dest.Tags)
+		chk.Error(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"IDs": "1,2"})))
+	}
+	{
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Tags": "a|b"}), set.SplitStrings("|")))
+		chk.Equal([]string{"a", "b"}, dest.Tags)
+	}
+	{ // Struct-tag
+		type Tagged struct {
+			Tags  []string `set:",csv" json:"tags"`
+			Paths []string `set:",csv=:" json:"paths"`
+			Plain []string `json:"plain"`
+		}
+		getter := set.MapGetter(map[string]interface{}{
+			"Tags": "a,b", "Paths": "/bin:/usr/bin", "Plain": "a,b",
+			"tags": "c,d", "paths": "/sbin", "plain": "c,d",
+		})
+		var dest Tagged
+		chk.NoError(set.V(&dest).Fill(getter))
+		chk.Equal(Tagged{[]string{"a", "b"}, []string{"/bin", "/usr/bin"}, []string{"a,b"}}, dest)
+		chk.NoError(set.V(&dest).FillByTag("json", getter))
+		chk.Equal(Tagged{[]string{"c", "d"}, []string{"/sbin"}, []string{"c,d"}}, dest)
+	}
+}
+
+func TestFillOption_skipUnchanged(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+		Zip  string
+	}
+	type Item struct {
+		SKU string
+	}
+	type T struct {
+		Name    string
+		Age     int
+		Score   *float64
+		Tags    []string
+		Address Address
+		Items   []Item
+		Meta    map[string]int
+	}
+	current := func() T {
+		score := 9.5
+		return T{
+			Name:    "bob",
+			Age:     30,
+			Score:   &score,
+			Tags:    []string{"a", "b"},
+			Address: Address{City: "Paris", Zip: "75001"},
+			Items:   []Item{{SKU: "x"}},
+			Meta:    map[string]int{"n": 1},
+		}
+	}
+	data := map[string]interface{}{
+		"Name":    "bob",
+		"Age":     "31",
+		"Score":   "9.5",
+		"Tags":    []interface{}{"a", "b"},
+		"Address": map[string]interface{}{"City": "Lyon", "Zip": "75001"},
+		"Items":   []map[string]interface{}{{"SKU": "x"}},
+		"Meta":    map[string]interface{}{"n": "1"},
+	}
+	{ // Equal values are skipped and only the changes are reported.
+		s := current()
+		tags, meta, score := s.Tags, s.Meta, s.Score
+		var changed []string
+		chk.NoError(set.V(&s).Fill(set.MapGetter(data), set.SkipUnchanged(func(field string) {
+			changed = append(changed, field)
+		})))
+		chk.Equal([]string{"Age", "Address.City"}, changed)
+		expect := current()
+		expect.Age, expect.Address.City = 31, "Lyon"
+		chk.Equal(expect, s)
+		chk.True(&tags[0] == &s.Tags[0]) // The existing slice and map are kept.
+		meta["m"] = 2
+		chk.Equal(2, s.Meta["m"])
+		chk.True(s.Score == score)
+	}
+	{ // Changed slices and maps are reported as a whole.
+		s := current()
+		s.Tags, s.Items, s.Meta = []string{"a"}, nil, map[string]int{"n": 2}
+		var changed []string
+		chk.NoError(set.V(&s).Fill(set.MapGetter(data), set.SkipUnchanged(func(field string) {
+			changed = append(changed, field)
+		})))
+		chk.Equal([]string{"Age", "Tags", "Address.City", "Items", "Meta"}, changed)
+		chk.Equal([]string{"a", "b"}, s.Tags)
+		chk.Equal([]Item{{SKU: "x"}}, s.Items)
+		chk.Equal(map[string]int{"n": 1}, s.Meta)
+	}
+	{ // Fields missing from the Getter are still zeroed; the callback is optional.
+		s := current()
+		chk.NoError(set.V(&s).FillByTags(nil, set.MapGetter(map[string]interface{}{"Name": "bob"}), set.SkipUnchanged(nil)))
+		zero := 0.0
+		chk.Equal(T{Name: "bob", Score: &zero}, s)
+	}
+	{ // Without the option every field is assigned.
+		s := current()
+		tags := s.Tags
+		chk.NoError(set.V(&s).Fill(set.MapGetter(data)))
+		chk.False(&tags[0] == &s.Tags[0])
+	}
+}
@@ -2,6 +2,9 @@ package set
 
 import (
 	"reflect"
+	"strings"
+
+	"github.com/nofeaturesonlybugs/errors"
 )
 
 // Writable attempts to make a reflect.Value usable for writing.  It will follow and instantiate nil pointers if necessary.
@@ -27,3 +30,205 @@ func Writable(v reflect.Value) (V reflect.Value, CanWrite bool) {
 	CanWrite = V.CanSet()
 	return
 }
+
+// Coerce coerces the scalar src into the scalar pointed at by dst using the same scalar rules as Value.To(); it is
+// a lighter alternative to Value.To() when only a single scalar needs to be converted.
+//	var port int
+//	err := set.Coerce(&port, "8080")
+//
+// dst must be a non-nil pointer to a bool, number, or string; pointers to pointers are instantiated as
+// necessary.  If src is nil, or a nil pointer, the value pointed at by dst is set to its zero value.
+func Coerce(dst, src interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return errors.Errorf("Coerce expects a non-nil pointer destination; got [%T]", dst)
+	}
+	target, _ := Writable(dv)
+	if !TypeCache.StatType(target.Type()).IsScalar {
+		return errors.Errorf("Coerce expects a pointer to a scalar destination; got [%T]", dst)
+	}
+	value := reflect.ValueOf(src)
+	for ; value.Kind() == reflect.Ptr; value = value.Elem() {
+		if value.IsNil() {
+			break
+		}
+	}
+	if !value.IsValid() || value.Kind() == reflect.Ptr {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	} else if value.Type() == target.Type() {
+		target.Set(value)
+		return checkEnum(target)
+	}
+	return errors.Go(coerce(target, value))
+}
+
+// StructByTag copies fields from the struct src into the struct dst by matching the value of the struct-tag
+// key between the two types; the Go field names do not need to match.  Options following a comma in
+// the tag value are ignored.  Tagged fields without a counterpart in the other struct are skipped.
+//
+// dst must be writable, i.e. pass the address of your destination struct.  Values are assigned
+// with Value.To() and are type-coerced if necessary.
+//
+// src is never altered; fields of src that are nil pointers are skipped and the matching fields of dst are
+// left as they are.
+func StructByTag(key string, dst, src interface{}) error {
+	dv, sv := V(dst), V(src)
+	if !dv.IsStruct || !sv.IsStruct {
+		return errors.Errorf("StructByTag expects struct arguments; got [%T] and [%T]", dst, src)
+	} else if !dv.CanWrite {
+		return errors.Errorf(dv.errorUnsupported("StructByTag"))
+	}
+	// The source is read through indirect() rather than sv.Fields() because Writable() would instantiate the
+	// nil pointers in src.
+	source := indirect(reflect.ValueOf(src))
+	if !source.IsValid() {
+		return nil
+	}
+	sources := map[string]reflect.Value{}
+	for k, size := 0, source.NumField(); k < size; k++ {
+		f := source.Type().Field(k)
+		value, ok := f.Tag.Lookup(key)
+		if name := strings.SplitN(value, ",", 2)[0]; ok && name != "" && name != "-" && f.PkgPath == "" {
+			sources[name] = source.Field(k)
+		}
+	}
+	for _, f := range dv.FieldsByTag(key) {
+		name := strings.SplitN(f.TagValue, ",", 2)[0]
+		sf, ok := sources[name]
+		if !ok || f.Field.PkgPath != "" || (sf.Kind() == reflect.Ptr && sf.IsNil()) {
+			continue
+		}
+		if err := f.Value.To(sf.Interface()); err != nil {
+			return errors.Errorf("While setting [%v]: %v", name, err.Error())
+		}
+	}
+	return nil
+}
+
+// FieldTagKeys returns the names in the struct-tag tag of the exported fields of the struct v, or of the struct
+// v points at, in declaration order; options following a comma in the tag value are ignored, a tag without a
+// name is named by the field, and fields tagged exactly "-" or without the tag are skipped.  nil is returned
+// if v is not a struct.
+//
+// Tagged fields that are structs, or pointers to structs, are described by the keys of their own fields joined
+// to the field's key with a period; if none of their fields have the tag then the field's own key is returned.
+// The fields of embedded structs without the tag are returned without a prefix.  Atomic types such as
+// time.Time are not descended into.
+//	type Address struct {
+//		City string `json:"city"`
+//	}
+//	type T struct {
+//		Name  string  `json:"name,omitempty"`
+//		Home  Address `json:"home"`
+//		Token string  `json:"-"`
+//	}
+//	set.FieldTagKeys(T{}, "json") // []string{"name", "home.city"}
+func FieldTagKeys(v interface{}, tag string) []string {
+	T := reflect.TypeOf(v)
+	for T != nil && T.Kind() == reflect.Ptr {
+		T = T.Elem()
+	}
+	if T == nil || T.Kind() != reflect.Struct {
+		return nil
+	}
+	return fieldTagKeys(T, tag, "", nil, map[reflect.Type]bool{})
+}
+
+// fieldTagKeys appends the keys for FieldTagKeys() of the struct type T to rv with prefix prepended; visiting
+// contains the struct types being described so recursive types are not descended into again.
+func fieldTagKeys(T reflect.Type, tag string, prefix string, rv []string, visiting map[reflect.Type]bool) []string {
+	visiting[T] = true
+	defer delete(visiting, T)
+	for k, size := 0, T.NumField(); k < size; k++ {
+		f := T.Field(k)
+		value, tagged := f.Tag.Lookup(tag)
+		nested := f.Type
+		for nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+		descend := nested.Kind() == reflect.Struct && !isAtomic(nested) && !visiting[nested]
+		if f.Anonymous && !tagged && descend {
+			rv = fieldTagKeys(nested, tag, prefix, rv, visiting)
+			continue
+		} else if f.PkgPath != "" || !tagged || value == "-" {
+			continue
+		}
+		name := strings.SplitN(value, ",", 2)[0]
+		if name == "" {
+			name = f.Name
+		}
+		if descend {
+			before := len(rv)
+			if rv = fieldTagKeys(nested, tag, prefix+name+".", rv, visiting); len(rv) > before {
+				continue
+			}
+		}
+		rv = append(rv, prefix+name)
+	}
+	return rv
+}
+
+// Scan fills the struct dst from the parallel slices columns and values, such as those produced by scanning a
+// database row, by matching each column to the field whose struct-tag tag has the column as its name.  Options
+// following a comma in the tag value are ignored.  Values are assigned with Value.To() and are type-coerced
+// if necessary; a value that is a []byte, as drivers commonly return for text, is treated as a string unless
+// the field is itself a slice.
+//
+// A nil value, such as a NULL column, sets a pointer field to nil and any other field to its zero value.
+// Columns that do not match a field are ignored and fields that do not match a column are not altered.
+//	columns, _ := rows.Columns()
+//	values := make([]interface{}, len(columns))
+//	for k := range values {
+//		values[k] = new(interface{})
+//	}
+//	for rows.Next() {
+//		err = rows.Scan(values...)
+//		var t T
+//		err = set.Scan(&t, columns, values, "db")
+//	}
+func Scan(dst interface{}, columns []string, values []interface{}, tag string) error {
+	dv := V(dst)
+	if !dv.IsStruct {
+		return errors.Errorf("Scan expects a struct argument; got [%T]", dst)
+	} else if !dv.CanWrite {
+		return errors.Errorf(dv.errorUnsupported("Scan"))
+	} else if len(columns) != len(values) {
+		return errors.Errorf("Scan expects the same number of columns and values; got %v and %v", len(columns), len(values))
+	}
+	indexes := make(map[string]int, len(columns))
+	for k, column := range columns {
+		indexes[column] = k
+	}
+	for _, f := range dv.FieldsByTag(tag) {
+		name := strings.SplitN(f.TagValue, ",", 2)[0]
+		k, ok := indexes[name]
+		if !ok || name == "" || f.Field.PkgPath != "" {
+			continue
+		}
+		value := reflect.ValueOf(values[k])
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				break
+			}
+			value = value.Elem()
+		}
+		if !value.IsValid() || value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			// A nil value, i.e. NULL, sets pointer fields to nil and other fields to their zero value.
+			if top := f.Value.TopValue; top.Kind() == reflect.Ptr && top.CanSet() {
+				top.Set(reflect.Zero(top.Type()))
+			} else if err := f.Value.Zero(); err != nil {
+				return errors.Errorf("While setting column [%v]: %v", name, err.Error())
+			}
+			continue
+		}
+		arg := value.Interface()
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 && !f.Value.IsSlice {
+			arg = string(value.Bytes())
+		}
+		if err := f.Value.To(arg); err != nil {
+			return errors.Errorf("While setting column [%v]: %v", name, err.Error())
+		}
+	}
+	return nil
+}
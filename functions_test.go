@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -59,6 +60,178 @@ func TestWritable(t *testing.T) {
 	}
 }
 
+func TestCoerce(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var port int
+		chk.NoError(set.Coerce(&port, "8080"))
+		chk.Equal(8080, port)
+		chk.NoError(set.Coerce(&port, 3.9))
+		chk.Equal(3, port)
+		chk.NoError(set.Coerce(&port, true))
+		chk.Equal(1, port)
+		chk.Error(set.Coerce(&port, "abc"))
+		chk.Equal(0, port)
+	}
+	{
+		var s string
+		chk.NoError(set.Coerce(&s, 42))
+		chk.Equal("42", s)
+		chk.NoError(set.Coerce(&s, "same"))
+		chk.Equal("same", s)
+		n := 7
+		chk.NoError(set.Coerce(&s, &n))
+		chk.Equal("7", s)
+		chk.NoError(set.Coerce(&s, nil))
+		chk.Equal("", s)
+		var np *int
+		s = "x"
+		chk.NoError(set.Coerce(&s, np))
+		chk.Equal("", s)
+	}
+	{
+		var b bool
+		chk.NoError(set.Coerce(&b, " true "))
+		chk.True(b)
+		var fp *float64
+		chk.NoError(set.Coerce(&fp, "1.5"))
+		chk.Equal(1.5, *fp)
+	}
+	{ // Errors.
+		var i int
+		chk.Error(set.Coerce(i, "1"))
+		var ip *int
+		chk.Error(set.Coerce(ip, "1"))
+		var slice []int
+		chk.Error(set.Coerce(&slice, "1"))
+		chk.Error(set.Coerce(&i, []int{1}))
+	}
+}
+
+func TestStructByTag(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Source struct {
+		ID       int    `map:"user_id"`
+		Name     string `map:"name,omitempty"`
+		Age      string `map:"age"`
+		Password string `map:"password"`
+		Ignored  string
+		private  string `map:"private"`
+	}
+	type Dest struct {
+		UserID   uint   `map:"user_id"`
+		FullName string `map:"name"`
+		Years    int    `map:"age"`
+		Email    string `map:"email"`
+		private  string `map:"private"`
+	}
+	{
+		src := Source{ID: 42, Name: "Bob", Age: "30", Password: "secret", Ignored: "x", private: "p"}
+		dst := Dest{Email: "bob@example.com"}
+		err := set.StructByTag("map", &dst, src)
+		chk.NoError(err)
+		chk.Equal(uint(42), dst.UserID)
+		chk.Equal("Bob", dst.FullName)
+		chk.Equal(30, dst.Years)
+		chk.Equal("bob@example.com", dst.Email)
+		chk.Equal("", dst.private)
+	}
+	{ // Source can be a pointer.
+		src := &Source{ID: 13, Age: "0"}
+		var dst Dest
+		err := set.StructByTag("map", &dst, src)
+		chk.NoError(err)
+		chk.Equal(uint(13), dst.UserID)
+	}
+	{ // Coercion errors are returned.
+		src := Source{Age: "old"}
+		var dst Dest
+		err := set.StructByTag("map", &dst, src)
+		chk.Error(err)
+	}
+	{ // Destination must be writable.
+		var dst Dest
+		err := set.StructByTag("map", dst, Source{})
+		chk.Error(err)
+	}
+	{ // Both must be structs.
+		var dst Dest
+		err := set.StructByTag("map", &dst, 42)
+		chk.Error(err)
+	}
+	{ // The source is not altered and its nil pointers are skipped.
+		type PtrSource struct {
+			ID   *int    `map:"user_id"`
+			Name *string `map:"name"`
+			Opt  *string
+		}
+		name := "Bob"
+		src := PtrSource{Name: &name}
+		dst := Dest{UserID: 7}
+		chk.NoError(set.StructByTag("map", &dst, &src))
+		chk.Nil(src.ID)
+		chk.Nil(src.Opt)
+		chk.Equal(uint(7), dst.UserID)
+		chk.Equal("Bob", dst.FullName)
+		var nilSrc *PtrSource
+		chk.NoError(set.StructByTag("map", &dst, nilSrc))
+		chk.Nil(nilSrc)
+	}
+}
+
+func TestScan(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Row struct {
+		ID      int     `db:"id"`
+		Name    string  `db:"name,omitempty"`
+		Score   float64 `db:"score"`
+		Data    []byte  `db:"data"`
+		Nick    *string `db:"nick"`
+		Skipped string  `db:"skipped"`
+		NoTag   string
+	}
+	{
+		columns := []string{"id", "name", "score", "data", "nick", "extra"}
+		var nick interface{}
+		values := []interface{}{int64(7), []byte("Bob"), "1.5", []byte("raw"), &nick, "ignored"}
+		row := Row{Skipped: "keep", NoTag: "keep"}
+		chk.NoError(set.Scan(&row, columns, values, "db"))
+		chk.Equal(7, row.ID)
+		chk.Equal("Bob", row.Name)
+		chk.Equal(1.5, row.Score)
+		chk.Equal([]byte("raw"), row.Data)
+		chk.Nil(row.Nick)
+		chk.Equal("keep", row.Skipped)
+		chk.Equal("keep", row.NoTag)
+	}
+	{ // Values scanned into *interface{}.
+		columns := []string{"id", "nick"}
+		values := make([]interface{}, len(columns))
+		for k := range values {
+			values[k] = new(interface{})
+		}
+		*(values[0].(*interface{})) = "12"
+		*(values[1].(*interface{})) = []byte("bobby")
+		var row Row
+		chk.NoError(set.Scan(&row, columns, values, "db"))
+		chk.Equal(12, row.ID)
+		chk.NotNil(row.Nick)
+		chk.Equal("bobby", *row.Nick)
+	}
+	{ // Errors.
+		var row Row
+		err := set.Scan(&row, []string{"id"}, []interface{}{"abc"}, "db")
+		chk.Error(err)
+		chk.Contains(err.Error(), "[id]")
+		chk.Error(set.Scan(&row, []string{"id"}, []interface{}{}, "db"))
+		chk.Error(set.Scan(row, []string{"id"}, []interface{}{1}, "db"))
+		chk.Error(set.Scan(new(int), []string{"id"}, []interface{}{1}, "db"))
+	}
+}
+
 func ExampleWritable() {
 	var value, writable reflect.Value
 	var ok bool
@@ -82,3 +255,62 @@ func ExampleWritable() {
 	// ok= false
 	// ok= true sp= Hello
 }
+
+func TestFieldTagKeys(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip,omitempty"`
+	}
+	type Audit struct {
+		CreatedBy string `json:"created_by"`
+	}
+	type Node struct {
+		Name string `json:"name"`
+		Next *Node  `json:"next"`
+	}
+	type Account struct {
+		Audit
+		ID       int      `json:"id,string"`
+		Email    string   `json:",omitempty"`
+		Home     Address  `json:"home"`
+		Work     *Address `json:"work"`
+		Untagged Address
+		Token    string      `json:"-"`
+		Born     time.Time   `json:"born"`
+		Empty    struct{}    `json:"empty"`
+		Tree     Node        `json:"tree"`
+		Inner    *Audit      `json:"inner,omitempty"`
+		Tags     []string    `json:"tags"`
+		Ptr      **Address   `json:"ptr"`
+		Times    []time.Time `json:"times"`
+	}
+	expect := []string{
+		"created_by",
+		"id",
+		"Email",
+		"home.city", "home.zip",
+		"work.city", "work.zip",
+		"born",
+		"empty",
+		"tree.name", "tree.next",
+		"inner.created_by",
+		"tags",
+		"ptr.city", "ptr.zip",
+		"times",
+	}
+	chk.Equal(expect, set.FieldTagKeys(Account{}, "json"))
+	chk.Equal(expect, set.FieldTagKeys(&Account{}, "json"))
+	var nilAccount *Account
+	chk.Equal(expect, set.FieldTagKeys(nilAccount, "json"))
+	//
+	chk.Nil(set.FieldTagKeys(Account{}, "db"))
+	type Row struct {
+		ID      int    `db:"id"`
+		private string `db:"private"`
+	}
+	chk.Equal([]string{"id"}, set.FieldTagKeys(Row{}, "db"))
+	chk.Nil(set.FieldTagKeys(42, "json"))
+	chk.Nil(set.FieldTagKeys(nil, "json"))
+}
@@ -2,6 +2,7 @@ package set
 
 import (
 	"reflect"
+	"strings"
 )
 
 // Getter returns a value by name.
@@ -18,8 +19,18 @@ func (me GetterFunc) Get(name string) interface{} {
 	return me(name)
 }
 
+// KeysGetter is a Getter that can also report its keys; Value.FillInsensitive() uses the keys to
+// match names that differ only in case.  The Getter returned by MapGetter() implements KeysGetter.
+type KeysGetter interface {
+	Getter
+	// Keys returns the names that can be passed to Get().
+	Keys() []string
+}
+
 // MapGetter accepts a map and returns a Getter.  Map keys need to be either interface{}
 // or string; i.e. the map needs to be of type map[string]* or map[interface{}]*.
+//
+// The returned Getter also implements KeysGetter.
 func MapGetter(m interface{}) Getter {
 	rv := GetterFunc(func(key string) interface{} { return nil })
 	//
@@ -28,28 +39,223 @@ func MapGetter(m interface{}) Getter {
 	if k != reflect.Map {
 		return rv
 	}
-	if t.Key().Kind() != reflect.String && t.Key().Kind() != reflect.Interface {
+	if !isStringKeyed(t) {
 		return rv
 	}
 	//
-	rv = GetterFunc(func(key string) interface{} {
-		if reflected := v.MapIndex(reflect.ValueOf(key)); reflected.IsValid() {
-			value := V(reflected.Interface())
-			if value.IsMap {
-				return MapGetter(reflected.Interface())
-			} else if value.IsSlice && value.ElemTypeInfo.IsMap {
-				getterSlice := []Getter{}
-				for k, max := 0, value.WriteValue.Len(); k < max; k++ {
-					getterSlice = append(getterSlice, MapGetter(value.WriteValue.Index(k).Interface()))
-				}
-				return getterSlice
-			} else {
-				return reflected.Interface()
+	return mapGetter{m: v}
+}
+
+// isStringKeyed returns true if the map type T has keys of kind string or interface{}; MapGetter() accepts such
+// maps.
+func isStringKeyed(T reflect.Type) bool {
+	K := T.Key().Kind()
+	return K == reflect.String || K == reflect.Interface
+}
+
+// mapGetter is the Getter returned by MapGetter for a valid map.
+type mapGetter struct {
+	m reflect.Value
+}
+
+// Get accepts a name and returns the value.
+func (me mapGetter) Get(key string) interface{} {
+	if reflected := me.m.MapIndex(reflect.ValueOf(key)); reflected.IsValid() {
+		value := V(reflected.Interface())
+		if value.IsMap {
+			return MapGetter(reflected.Interface())
+		} else if value.IsSlice && value.ElemTypeInfo.IsMap {
+			getterSlice := []Getter{}
+			for k, max := 0, value.WriteValue.Len(); k < max; k++ {
+				getterSlice = append(getterSlice, MapGetter(value.WriteValue.Index(k).Interface()))
 			}
+			return getterSlice
 		} else {
+			return reflected.Interface()
+		}
+	}
+	return nil
+}
+
+// Keys returns the map keys that are strings.
+func (me mapGetter) Keys() []string {
+	rv := make([]string, 0, me.m.Len())
+	for _, key := range me.m.MapKeys() {
+		if key.Kind() == reflect.Interface {
+			key = key.Elem()
+		}
+		if key.Kind() == reflect.String {
+			rv = append(rv, key.String())
+		}
+	}
+	return rv
+}
+
+// PrefixGetter returns a Getter that allows a flat key-value store to fill nested structs.  When the name passed
+// to Get() is not a key in getter but is a prefix of keys followed by separator then a sub-Getter is returned
+// whose names are prefixed automatically; e.g. with separator "/" the fields of a nested struct DB are
+// looked up with Get("DB/Host"), Get("DB/Port"), and so on.
+//
+// getter must implement KeysGetter, as the Getter returned by MapGetter() does, for nested structs to be
+// found; the returned Getter and its sub-Getters also implement KeysGetter.
+//	data := map[string]string{
+//		"Name":    "app",
+//		"DB/Host": "localhost",
+//		"DB/Port": "5432",
+//	}
+//	err := set.V(&config).Fill(set.PrefixGetter(set.MapGetter(data), "/"))
+func PrefixGetter(getter Getter, separator string) Getter {
+	rv := &prefixGetter{getter: getter, separator: separator, prefixes: map[string]struct{}{}}
+	if keys, ok := getter.(KeysGetter); ok && separator != "" {
+		rv.keys = keys.Keys()
+		for _, key := range rv.keys {
+			for start := 0; ; {
+				k := strings.Index(key[start:], separator)
+				if k == -1 {
+					break
+				}
+				start += k + len(separator)
+				rv.prefixes[key[:start]] = struct{}{}
+			}
+		}
+	}
+	return rv
+}
+
+// prefixGetter is the Getter returned by PrefixGetter.
+type prefixGetter struct {
+	getter    Getter
+	separator string
+	// prefix is prepended to every name passed to Get().
+	prefix string
+	// keys are the keys of getter and prefixes contains every prefix of those keys that ends in separator; they
+	// are shared with sub-Getters.
+	keys     []string
+	prefixes map[string]struct{}
+}
+
+// Get accepts a name and returns the value.
+func (me *prefixGetter) Get(name string) interface{} {
+	full := me.prefix + name
+	if value := me.getter.Get(full); value != nil {
+		return value
+	} else if _, ok := me.prefixes[full+me.separator]; ok {
+		rv := *me
+		rv.prefix = full + me.separator
+		return &rv
+	}
+	return nil
+}
+
+// Keys returns the keys beneath the prefix with the prefix removed as well as the names that return
+// sub-Getters.
+func (me *prefixGetter) Keys() []string {
+	var rv []string
+	seen := map[string]struct{}{}
+	add := func(key string) {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			rv = append(rv, key)
+		}
+	}
+	for _, key := range me.keys {
+		if !strings.HasPrefix(key, me.prefix) {
+			continue
+		}
+		key = key[len(me.prefix):]
+		add(key)
+		if k := strings.Index(key, me.separator); k != -1 && me.separator != "" {
+			add(key[:k])
+		}
+	}
+	return rv
+}
+
+// insensitiveGetter wraps a Getter; if the wrapped Getter returns nil for a name and implements KeysGetter then
+// the name is retried with the key that matches it without regard to case.
+type insensitiveGetter struct {
+	getter Getter
+	// lower maps lowercased keys to keys; it is created on the first retry.
+	lower map[string]string
+}
+
+// Get accepts a name and returns the value.
+func (me *insensitiveGetter) Get(name string) interface{} {
+	if got := me.getter.Get(name); got != nil {
+		return got
+	}
+	keys, ok := me.getter.(KeysGetter)
+	if !ok {
+		return nil
+	}
+	if me.lower == nil {
+		me.lower = map[string]string{}
+		for _, key := range keys.Keys() {
+			lowered := strings.ToLower(key)
+			if existing, exists := me.lower[lowered]; !exists || key < existing {
+				me.lower[lowered] = key // The smallest key wins when keys differ only in case.
+			}
+		}
+	}
+	if key, ok := me.lower[strings.ToLower(name)]; ok && key != name {
+		return me.getter.Get(key)
+	}
+	return nil
+}
+
+// StructGetter accepts a struct, or pointer to struct, and returns a Getter; Get(name) returns the value of
+// the exported field with the given name, including fields promoted from embedded structs.
+//
+// Fields that are themselves structs are returned as a Getter and fields that are slices of structs
+// are returned as a []Getter; this allows a struct to be the data source when calling Fill() on
+// another struct.  Atomic types, such as time.Time, are returned as values.
+func StructGetter(s interface{}) Getter {
+	return structGetter(V(s).WriteValue)
+}
+
+// structGetter returns the Getter for StructGetter.
+func structGetter(v reflect.Value) Getter {
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return GetterFunc(func(key string) interface{} { return nil })
+	}
+	return GetterFunc(func(key string) interface{} {
+		field, ok := v.Type().FieldByName(key)
+		if !ok || field.PkgPath != "" {
 			return nil
 		}
+		fv := v
+		for _, n := range field.Index {
+			if fv = indirect(fv); !fv.IsValid() {
+				return nil // Promoted through a nil embedded pointer.
+			}
+			fv = fv.Field(n)
+		}
+		if fv = indirect(fv); !fv.IsValid() {
+			return nil
+		}
+		if fv.Kind() == reflect.Struct && !isAtomic(fv.Type()) {
+			return structGetter(fv)
+		} else if fv.Kind() == reflect.Slice {
+			if elemInfo := TypeCache.StatType(fv.Type().Elem()); elemInfo.IsStruct && !isAtomic(elemInfo.Type) {
+				getters := make([]Getter, fv.Len())
+				for k := range getters {
+					getters[k] = structGetter(indirect(fv.Index(k)))
+				}
+				return getters
+			}
+		}
+		return fv.Interface()
 	})
-	//
-	return rv
+}
+
+// indirect follows the pointer chain in v without instantiating nil pointers; if a nil pointer is
+// encountered the zero reflect.Value is returned.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
 }
@@ -1,7 +1,9 @@
 package set_test
 
 import (
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -20,3 +22,192 @@ func TestMapGetter_codeCoverage(t *testing.T) {
 		chk.Nil(g.Get("foo"))
 	}
 }
+
+func TestStructGetter(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+		Zip  int
+	}
+	type Base struct {
+		ID int
+	}
+	type Source struct {
+		*Base
+		Name      string
+		Age       string
+		Address   Address
+		Work      *Address
+		Vacation  *Address
+		Previous  []Address
+		Pointers  []*Address
+		Born      time.Time
+		Nicknames []string
+		private   string
+	}
+	type Dest struct {
+		ID        uint
+		Name      string
+		Age       int
+		Address   Address
+		Work      *Address
+		Vacation  *Address
+		Previous  []Address
+		Pointers  []*Address
+		Born      time.Time
+		Nicknames []string
+		private   string
+	}
+	born := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := Source{
+		Base:      &Base{ID: 42},
+		Name:      "Bob",
+		Age:       "30",
+		Address:   Address{City: "Big City", Zip: 12345},
+		Work:      &Address{City: "Work City"},
+		Previous:  []Address{{City: "Old City"}, {City: "Older City"}},
+		Pointers:  []*Address{{City: "Pointer City"}},
+		Born:      born,
+		Nicknames: []string{"Bobby"},
+		private:   "private",
+	}
+	{
+		getter := set.StructGetter(src)
+		chk.Equal("Bob", getter.Get("Name"))
+		chk.Equal(42, getter.Get("ID"))
+		chk.Nil(getter.Get("private"))
+		chk.Nil(getter.Get("NotFound"))
+		chk.Nil(getter.Get("Vacation"))
+		chk.Equal(born, getter.Get("Born"))
+		address, ok := getter.Get("Address").(set.Getter)
+		chk.True(ok)
+		chk.Equal("Big City", address.Get("City"))
+		previous, ok := getter.Get("Previous").([]set.Getter)
+		chk.True(ok)
+		chk.Equal(2, len(previous))
+		chk.Equal("Older City", previous[1].Get("City"))
+	}
+	{ // Fill from a struct.
+		var dst Dest
+		err := set.V(&dst).Fill(set.StructGetter(&src))
+		chk.NoError(err)
+		chk.Equal(uint(42), dst.ID)
+		chk.Equal("Bob", dst.Name)
+		chk.Equal(30, dst.Age)
+		chk.Equal(src.Address, dst.Address)
+		chk.Equal(src.Work, dst.Work)
+		chk.False(src.Work == dst.Work)
+		chk.Equal(&Address{}, dst.Vacation) // Fill instantiates nil pointers.
+		chk.Equal(src.Previous, dst.Previous)
+		chk.Equal(src.Pointers, dst.Pointers)
+		chk.Equal(born, dst.Born)
+		chk.Equal(src.Nicknames, dst.Nicknames)
+		chk.Equal("", dst.private)
+	}
+	{ // Nil embedded pointers are not instantiated in the source.
+		src := Source{Name: "Alice"}
+		getter := set.StructGetter(&src)
+		chk.Nil(getter.Get("ID"))
+		chk.Nil(src.Base)
+	}
+	{ // Not a struct.
+		chk.Nil(set.StructGetter(42).Get("Name"))
+		var sp *Source
+		chk.Nil(set.StructGetter(sp).Get("Name"))
+	}
+}
+
+func TestMapGetter_keys(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		getter, ok := set.MapGetter(map[string]int{"a": 1, "b": 2}).(set.KeysGetter)
+		chk.True(ok)
+		keys := getter.Keys()
+		sort.Strings(keys)
+		chk.Equal([]string{"a", "b"}, keys)
+	}
+	{
+		getter, ok := set.MapGetter(map[interface{}]int{"a": 1, 2: 2}).(set.KeysGetter)
+		chk.True(ok)
+		chk.Equal([]string{"a"}, getter.Keys())
+	}
+	{
+		_, ok := set.MapGetter(42).(set.KeysGetter)
+		chk.False(ok)
+	}
+}
+
+func TestPrefixGetter(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Pool struct {
+		Min, Max int
+	}
+	type DB struct {
+		Host string
+		Port int
+		Pool Pool
+	}
+	type Config struct {
+		Name  string
+		DB    DB
+		Cache *DB
+		Other DB
+	}
+	data := map[string]string{
+		"Name":         "app",
+		"DB/Host":      "localhost",
+		"DB/Port":      "5432",
+		"DB/Pool/Min":  "1",
+		"DB/Pool/Max":  "10",
+		"Cache/Host":   "cache",
+		"Cache/Port":   "6379",
+		"Unused/Thing": "x",
+	}
+	{
+		var c Config
+		chk.NoError(set.V(&c).Fill(set.PrefixGetter(set.MapGetter(data), "/")))
+		chk.Equal("app", c.Name)
+		chk.Equal(DB{Host: "localhost", Port: 5432, Pool: Pool{Min: 1, Max: 10}}, c.DB)
+		chk.NotNil(c.Cache)
+		chk.Equal(DB{Host: "cache", Port: 6379}, *c.Cache)
+		chk.Equal(DB{}, c.Other)
+	}
+	{ // Configurable separator.
+		dotted := map[string]string{"Name": "app", "DB.Host": "h", "DB.Pool.Max": "3"}
+		var c Config
+		chk.NoError(set.V(&c).Fill(set.PrefixGetter(set.MapGetter(dotted), ".")))
+		chk.Equal("h", c.DB.Host)
+		chk.Equal(3, c.DB.Pool.Max)
+		// The separator must match.
+		c = Config{}
+		chk.NoError(set.V(&c).Fill(set.PrefixGetter(set.MapGetter(dotted), "/")))
+		chk.Equal("", c.DB.Host)
+	}
+	{ // Works with FillInsensitive.
+		lower := map[string]string{"name": "app", "db/host": "h", "db/pool/min": "2"}
+		var c Config
+		chk.NoError(set.V(&c).FillInsensitive(set.PrefixGetter(set.MapGetter(lower), "/")))
+		chk.Equal("app", c.Name)
+		chk.Equal("h", c.DB.Host)
+		chk.Equal(2, c.DB.Pool.Min)
+	}
+	{ // Keys.
+		getter := set.PrefixGetter(set.MapGetter(data), "/").Get("DB").(set.KeysGetter)
+		keys := getter.Keys()
+		sort.Strings(keys)
+		chk.Equal([]string{"Host", "Pool", "Pool/Max", "Pool/Min", "Port"}, keys)
+	}
+	{ // Getters without keys only find exact names.
+		getter := set.PrefixGetter(set.GetterFunc(func(name string) interface{} {
+			if value, ok := data[name]; ok {
+				return value
+			}
+			return nil
+		}), "/")
+		chk.Equal("app", getter.Get("Name"))
+		chk.Nil(getter.Get("DB"))
+	}
+}
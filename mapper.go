@@ -6,16 +6,10 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/nofeaturesonlybugs/errors"
 )
 
-var mapperTreatAsScalar = map[reflect.Type]struct{}{
-	reflect.TypeOf(time.Time{}):  {},
-	reflect.TypeOf(&time.Time{}): {},
-}
-
 // Mapping is the result of traversing nested structures to generate a mapping of Key-to-Fields.
 type Mapping struct {
 	// Keys is a slice of key names in the order they were encountered during the mapping.
@@ -205,9 +199,9 @@ func (me *Mapper) Map(T interface{}) *Mapping {
 				name = prefix
 			}
 			nameIndeces := append(indeces, k)
-			if _, ok := mapperTreatAsScalar[fieldTypeInfo.Type]; ok {
+			if isAtomic(fieldTypeInfo.Type) {
 				rv.Keys, rv.Indeces[name], rv.StructFields[name] = append(rv.Keys, name), nameIndeces, field
-			} else if _, ok = me.TreatAsScalar[fieldTypeInfo.Type]; ok {
+			} else if me.TreatAsScalar.Has(fieldTypeInfo.Type) {
 				rv.Keys, rv.Indeces[name], rv.StructFields[name] = append(rv.Keys, name), nameIndeces, field
 			} else if fieldTypeInfo.IsStruct {
 				scan(fieldTypeInfo, nameIndeces, name)
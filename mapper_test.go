@@ -3,6 +3,8 @@ package set_test
 import (
 	"database/sql"
 	"fmt"
+	"math/big"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -95,6 +97,30 @@ func TestMapper(t *testing.T) {
 	}
 }
 
+func TestMapper_atomicTypes(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Account struct {
+		Balance  *big.Int
+		Homepage url.URL
+		Name     string
+	}
+	mapper := &set.Mapper{Join: "_"}
+	mapping := mapper.Map(&Account{})
+	chk.Equal([]string{"Balance", "Homepage", "Name"}, mapping.Keys)
+	chk.Equal("[0]", fmt.Sprintf("%v", mapping.Get("Balance")))
+	chk.Equal("[1]", fmt.Sprintf("%v", mapping.Get("Homepage")))
+	chk.Nil(mapping.Get("Homepage_Host"))
+	//
+	account := Account{}
+	bound := mapper.Bind(&account)
+	chk.NoError(bound.Set("Balance", "12345678901234567890"))
+	chk.NoError(bound.Set("Homepage", "https://example.com/home"))
+	chk.NoError(bound.Err())
+	chk.Equal("12345678901234567890", account.Balance.String())
+	chk.Equal("example.com", account.Homepage.Host)
+}
+
 func TestMapper_Bind(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -0,0 +1,261 @@
+package set
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+// Options alter the behavior of a *Value; see Value.WithOptions().
+//
+// The zero value is the default behavior.
+type Options struct {
+	// SkipInvalidElems causes To() and ToAppend() to skip the elements of a source slice that can not be
+	// coerced into the destination slice's element type instead of failing the entire operation.  The
+	// elements that can be coerced are assigned and an ElemErrors describing the skipped elements is returned.
+	SkipInvalidElems bool
+
+	// NullTokens are strings that represent a null value.  When To() is called with a string equal to one of
+	// the tokens, without regard to case, and the destination is a pointer then the pointer is set to nil
+	// instead of being allocated and coerced; for example []string{"null", "nil", ""}.  Destinations that are
+	// not pointers are coerced as usual.
+	NullTokens []string
+
+	// Humanize enables parsing of humanized numeric strings when To() coerces a string into an int, uint, or
+	// float; thousands separators are removed and a trailing % divides the number by 100.  For example "1,234.56"
+	// becomes 1234.56 and "45%" becomes 0.45; the usual rules then apply so "45%" coerced into an int is 0.
+	Humanize bool
+	// ThousandsSeparator and DecimalSeparator are the separators used when Humanize is true; they default to
+	// "," and "." respectively.  Set them for other locales, e.g. "." and "," to parse "1.234,56"; when only
+	// DecimalSeparator is set to "," the ThousandsSeparator defaults to ".".  To() returns an error if the two
+	// separators are the same.
+	ThousandsSeparator string
+	DecimalSeparator   string
+
+	// TrimSpace causes To() to remove leading and trailing whitespace from string sources before they are assigned
+	// or parsed; for example " x " coerces into a string as "x", " null " matches the NullToken "null", and
+	// " 10.0.0.1 " can be unmarshaled into a net.IP.  Numeric, bool, and time parsing ignore surrounding whitespace
+	// regardless of this option.  Slice elements are trimmed individually.
+	TrimSpace bool
+
+	// EmptyAsZero causes To() to set numeric and bool destinations to their zero value, without an error, when
+	// the source is an empty or whitespace only string; e.g. optional form fields that were left blank.  Other
+	// destinations are not affected.
+	EmptyAsZero bool
+
+	// ClampUnsigned causes To() to set unsigned integer destinations to 0, without an error, when the source is a
+	// negative number or a string holding one.  By default such coercions are strict: To() returns an error and
+	// the destination is set to 0.
+	ClampUnsigned bool
+
+	// EpochUnit is the unit of the Unix epoch when To() coerces a number into a time.Time or a time.Time into a
+	// number; it must evenly divide time.Second, e.g. time.Millisecond, and defaults to time.Second.  Float
+	// destinations receive fractional units.
+	EpochUnit time.Duration
+
+	// ParseDurations causes To() to parse strings such as "1h30m" with time.ParseDuration() when the destination is
+	// a time.Duration; strings holding only a number are still a number of nanoseconds.
+	ParseDurations bool
+
+	// ParseByteSizes causes To() to parse strings holding a number followed by a size suffix, such as "10MB" or
+	// "1.5 GiB", into a number of bytes when the destination is an int or uint other than time.Duration.  Strings
+	// holding only a number are coerced as usual and an unknown suffix is an error.  Suffixes are matched without
+	// regard to case:
+	//	B			1
+	//	kB, MB, GB, TB, PB, EB	1000, 1000^2, 1000^3, 1000^4, 1000^5, 1000^6
+	//	KiB, MiB, GiB, TiB, PiB, EiB	1024, 1024^2, 1024^3, 1024^4, 1024^5, 1024^6
+	// A fractional size must be a whole number of bytes, e.g. "1.5KiB" is 1536 but "1.5B" is an error.
+	ParseByteSizes bool
+}
+
+// WithOptions returns a copy of Value that uses opts.  Values created internally by the copy, such as those
+// for slice elements and struct fields, also use opts.
+//	var t []int
+//	err := set.V(&t).WithOptions(set.Options{SkipInvalidElems: true}).To([]string{"1", "x", "3"})
+//	// t is []int{1, 3} and err is an ElemErrors describing index 1.
+func (me *Value) WithOptions(opts Options) *Value {
+	if me == nil {
+		return nil
+	}
+	rv := me.Copy()
+	rv.options = &opts
+	return rv
+}
+
+// opts returns the options for Value or the default options if none were set.
+func (me *Value) opts() Options {
+	if me.options == nil {
+		return Options{}
+	}
+	return *me.options
+}
+
+// v returns a new *Value for arg that uses the same options as me.
+func (me *Value) v(arg interface{}) *Value {
+	rv := V(arg)
+	rv.options = me.options
+	return rv
+}
+
+// isNullToken returns true if s is one of the configured null tokens.
+func (me *Value) isNullToken(s string) bool {
+	if me.options == nil {
+		return false
+	}
+	for _, token := range me.options.NullTokens {
+		if strings.EqualFold(s, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// epochUnit returns Options.EpochUnit or time.Second if it is not set.
+func (me *Value) epochUnit() (time.Duration, error) {
+	if me.options == nil || me.options.EpochUnit == 0 {
+		return time.Second, nil
+	} else if unit := me.options.EpochUnit; unit > 0 && time.Second%unit == 0 {
+		return unit, nil
+	}
+	return 0, errors.Errorf("EpochUnit %v does not evenly divide time.Second", me.options.EpochUnit)
+}
+
+// trimSpace returns arg with leading and trailing whitespace removed when arg is a string, a named string type,
+// or a non-nil pointer to either; all other values are returned unchanged.
+func trimSpace(arg interface{}) interface{} {
+	if str, ok := arg.(string); ok {
+		return strings.TrimSpace(str)
+	}
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return arg
+	}
+	return reflect.ValueOf(strings.TrimSpace(v.String())).Convert(v.Type()).Interface()
+}
+
+// humanize returns the humanized numeric string s, as described by Options.Humanize, with the separators
+// removed and normalized; if s ends in % then it is parsed and returned as a float64 divided by 100.
+func (me *Value) humanize(s string) (reflect.Value, error) {
+	thousands, decimal := me.options.ThousandsSeparator, me.options.DecimalSeparator
+	if decimal == "" {
+		decimal = "."
+	}
+	if thousands == "" && decimal == "," {
+		thousands = "."
+	} else if thousands == "" {
+		thousands = ","
+	}
+	if thousands == decimal {
+		return reflect.Value{}, errors.Errorf("ThousandsSeparator and DecimalSeparator are both %q", decimal)
+	}
+	s = strings.TrimSpace(s)
+	percent := strings.HasSuffix(s, "%")
+	if percent {
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+	s = strings.Replace(s, thousands, "", -1)
+	if decimal != "." {
+		s = strings.Replace(s, decimal, ".", -1)
+	}
+	if !percent {
+		return reflect.ValueOf(s), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return reflect.Value{}, errors.Go(err)
+	}
+	return reflect.ValueOf(f / 100), nil
+}
+
+// parseDuration returns s parsed as described by Options.ParseDurations; the second return value is false if s
+// holds only a number and should be coerced as usual.
+func parseDuration(s string) (reflect.Value, bool, error) {
+	s = strings.TrimSpace(s)
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return reflect.Value{}, false, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return reflect.Value{}, true, errors.Go(err)
+	}
+	return reflect.ValueOf(d), true, nil
+}
+
+// byteSizes are the multipliers for the suffixes recognized by Options.ParseByteSizes; the keys are lowercase.
+var byteSizes = map[string]int64{
+	"b":  1,
+	"kb": 1000, "mb": 1000 * 1000, "gb": 1000 * 1000 * 1000,
+	"tb": 1000 * 1000 * 1000 * 1000, "pb": 1000 * 1000 * 1000 * 1000 * 1000,
+	"eb": 1000 * 1000 * 1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50, "eib": 1 << 60,
+}
+
+// parseByteSize returns s parsed as described by Options.ParseByteSizes as an int64 or, if it is too large,
+// a uint64; the second return value is false if s has no suffix and should be coerced as usual.
+func parseByteSize(s string) (reflect.Value, bool, error) {
+	s = strings.TrimSpace(s)
+	end := 0
+	if end < len(s) && (s[end] == '+' || s[end] == '-') {
+		end++
+	}
+	for end < len(s) && (s[end] == '.' || (s[end] >= '0' && s[end] <= '9')) {
+		end++
+	}
+	number, suffix := s[:end], strings.TrimSpace(s[end:])
+	if suffix == "" {
+		return reflect.Value{}, false, nil
+	}
+	multiplier, ok := byteSizes[strings.ToLower(suffix)]
+	if !ok {
+		return reflect.Value{}, true, errors.Errorf("unknown size suffix %q", suffix)
+	}
+	size, ok := new(big.Rat).SetString(number)
+	if !ok || strings.ContainsAny(number, "/eE") {
+		return reflect.Value{}, true, errors.Errorf("invalid size %q", s)
+	}
+	size.Mul(size, new(big.Rat).SetInt64(multiplier))
+	if !size.IsInt() {
+		return reflect.Value{}, true, errors.Errorf("size %q is not a whole number of bytes", s)
+	} else if n := size.Num(); n.IsInt64() {
+		return reflect.ValueOf(n.Int64()), true, nil
+	} else if n.IsUint64() {
+		return reflect.ValueOf(n.Uint64()), true, nil
+	}
+	return reflect.Value{}, true, errors.Errorf("size %q overflows 64 bits", s)
+}
+
+// sizeOverflows returns true if size, an int64 or uint64 returned by parseByteSize, does not fit in the int or
+// uint target; negative sizes are left for the coercion into target to report or clamp.
+func sizeOverflows(target reflect.Value, size reflect.Value) bool {
+	switch {
+	case numericKind(target.Kind()) == "int" && size.Kind() == reflect.Uint64:
+		return size.Uint() > math.MaxInt64 || target.OverflowInt(int64(size.Uint()))
+	case numericKind(target.Kind()) == "int":
+		return target.OverflowInt(size.Int())
+	case size.Kind() == reflect.Uint64:
+		return target.OverflowUint(size.Uint())
+	}
+	return size.Int() > 0 && target.OverflowUint(uint64(size.Int()))
+}
+
+// setNilPointer sets the outermost settable pointer in the chain of pointers leading to Value to nil; the
+// return value is false if there is no such pointer.
+func (me *Value) setNilPointer() bool {
+	for v := me.TopValue; v.Kind() == reflect.Ptr; v = v.Elem() {
+		if v.CanSet() {
+			v.Set(reflect.Zero(v.Type()))
+			return true
+		} else if v.IsNil() {
+			break
+		}
+	}
+	return false
+}
@@ -0,0 +1,550 @@
+package set_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	stderrors "errors"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestOptions_skipInvalidElems(t *testing.T) {
+	chk := assert.New(t)
+	//
+	skip := set.Options{SkipInvalidElems: true}
+	{ // Default is all-or-nothing.
+		var ints []int
+		err := set.V(&ints).To([]string{"1", "x", "3"})
+		chk.Error(err)
+		chk.Nil(ints)
+	}
+	{ // Skipped elements are reported with their indices.
+		var ints []int
+		err := set.V(&ints).WithOptions(skip).To([]string{"1", "x", "3", "y"})
+		chk.Error(err)
+		chk.Equal([]int{1, 3}, ints)
+		elemErrs, ok := err.(set.ElemErrors)
+		chk.True(ok)
+		chk.Equal(2, len(elemErrs))
+		chk.Equal(1, elemErrs[0].Index)
+		chk.Equal(3, elemErrs[1].Index)
+		chk.Contains(err.Error(), "2 element(s) skipped")
+		chk.Contains(err.Error(), "Index 1:")
+		chk.Contains(err.Error(), "Index 3:")
+	}
+	{ // No errors.
+		var ints []int
+		err := set.V(&ints).WithOptions(skip).To([]string{"1", "2"})
+		chk.NoError(err)
+		chk.Equal([]int{1, 2}, ints)
+	}
+	{ // ToAppend appends the good elements.
+		ints := []int{0}
+		err := set.V(&ints).WithOptions(skip).ToAppend([]string{"1", "x", "3"})
+		chk.Error(err)
+		chk.Equal([]int{0, 1, 3}, ints)
+		ints = []int{0}
+		err = set.V(&ints).ToAppend([]string{"1", "x", "3"})
+		chk.Error(err)
+		chk.Equal([]int{0}, ints)
+	}
+	{ // Options are used when filling struct fields.
+		type T struct {
+			Ints []int
+		}
+		var t T
+		getter := set.MapGetter(map[string]interface{}{"Ints": []string{"1", "x", "3"}})
+		err := set.V(&t).WithOptions(skip).Fill(getter)
+		chk.Error(err)
+		chk.Equal([]int{1, 3}, t.Ints)
+		//
+		t = T{}
+		err = set.V(&t).Fill(getter)
+		chk.Error(err)
+		chk.Nil(t.Ints)
+	}
+	{ // Copy retains options.
+		var ints []int
+		v := set.V(&ints).WithOptions(skip).Copy()
+		chk.Error(v.To([]string{"x", "2"}))
+		chk.Equal([]int{2}, ints)
+	}
+	{
+		var v *set.Value
+		chk.Nil(v.WithOptions(skip))
+	}
+}
+
+func TestOptions_nullTokens(t *testing.T) {
+	chk := assert.New(t)
+	//
+	nulls := set.Options{NullTokens: []string{"null", "nil", ""}}
+	{ // Off by default.
+		var ip *int
+		chk.Error(set.V(&ip).To("null"))
+		chk.NotNil(ip)
+		var sp *string
+		chk.NoError(set.V(&sp).To("null"))
+		chk.Equal("null", *sp)
+	}
+	{
+		var ip *int
+		v := set.V(&ip).WithOptions(nulls)
+		chk.NoError(v.To("NULL"))
+		chk.Nil(ip)
+		chk.NoError(v.To("42"))
+		chk.Equal(42, *ip)
+		chk.NoError(v.To("nil"))
+		chk.Nil(ip)
+		var sp *string
+		v = set.V(&sp).WithOptions(nulls)
+		chk.NoError(v.To("hello"))
+		chk.Equal("hello", *sp)
+		chk.NoError(v.To(""))
+		chk.Nil(sp)
+	}
+	{ // Non-pointers are coerced as usual.
+		var i int
+		chk.Error(set.V(&i).WithOptions(nulls).To("null"))
+		var s string
+		chk.NoError(set.V(&s).WithOptions(nulls).To("null"))
+		chk.Equal("null", s)
+	}
+	{ // Fill.
+		type T struct {
+			Age  *int
+			Name *string
+			Nick *string
+		}
+		var t T
+		getter := set.MapGetter(map[string]string{"Age": "null", "Name": "Bob", "Nick": "nil"})
+		chk.NoError(set.V(&t).WithOptions(nulls).Fill(getter))
+		chk.Nil(t.Age)
+		chk.NotNil(t.Name)
+		chk.Equal("Bob", *t.Name)
+		chk.Nil(t.Nick)
+	}
+}
+
+func TestOptions_humanize(t *testing.T) {
+	chk := assert.New(t)
+	//
+	humanize := set.Options{Humanize: true}
+	{
+		var i int
+		chk.NoError(set.V(&i).WithOptions(humanize).To("1,234"))
+		chk.Equal(1234, i)
+		chk.NoError(set.V(&i).WithOptions(humanize).To("42"))
+		chk.Equal(42, i)
+		chk.NoError(set.V(&i).WithOptions(humanize).To("250%"))
+		chk.Equal(2, i)
+		chk.Error(set.V(&i).To("1,234")) // Off by default.
+		chk.Equal(0, i)
+	}
+	{
+		var f float64
+		chk.NoError(set.V(&f).WithOptions(humanize).To("1,234.56"))
+		chk.Equal(1234.56, f)
+		chk.NoError(set.V(&f).WithOptions(humanize).To(" 45 % "))
+		chk.Equal(0.45, f)
+		chk.NoError(set.V(&f).WithOptions(humanize).To("3.5"))
+		chk.Equal(3.5, f)
+		chk.Error(set.V(&f).WithOptions(humanize).To("abc%"))
+		chk.Equal(0.0, f)
+		var u uint
+		chk.NoError(set.V(&u).WithOptions(humanize).To("1,000,000"))
+		chk.Equal(uint(1000000), u)
+	}
+	{ // Locale separators.
+		var f float64
+		european := set.Options{Humanize: true, ThousandsSeparator: ".", DecimalSeparator: ","}
+		chk.NoError(set.V(&f).WithOptions(european).To("1.234,56"))
+		chk.Equal(1234.56, f)
+		chk.NoError(set.V(&f).WithOptions(european).To("12,5%"))
+		chk.Equal(0.125, f)
+	}
+	{ // A decimal comma on its own implies a thousands period; identical separators are an error.
+		var f float64
+		decimalComma := set.Options{Humanize: true, DecimalSeparator: ","}
+		chk.NoError(set.V(&f).WithOptions(decimalComma).To("1,5"))
+		chk.Equal(1.5, f)
+		chk.NoError(set.V(&f).WithOptions(decimalComma).To("1.234,5"))
+		chk.Equal(1234.5, f)
+		f = 7
+		err := set.V(&f).WithOptions(set.Options{Humanize: true, ThousandsSeparator: ",", DecimalSeparator: ","}).To("1,5")
+		chk.Error(err)
+		chk.Contains(err.Error(), "both")
+		chk.Equal(0.0, f)
+		err = set.V(&f).WithOptions(set.Options{Humanize: true, ThousandsSeparator: "."}).To("1.5")
+		chk.Error(err)
+	}
+	{ // Strings are not altered.
+		var s string
+		chk.NoError(set.V(&s).WithOptions(humanize).To("1,234"))
+		chk.Equal("1,234", s)
+	}
+	{ // Fill
+		type T struct {
+			Price float64
+			Ratio float64
+		}
+		var dest T
+		chk.NoError(set.V(&dest).WithOptions(humanize).Fill(set.MapGetter(map[string]interface{}{"Price": "1,299.99", "Ratio": "5%"})))
+		chk.Equal(T{1299.99, 0.05}, dest)
+	}
+}
+
+func TestOptions_trimSpace(t *testing.T) {
+	chk := assert.New(t)
+	//
+	trim := set.Options{TrimSpace: true}
+	{
+		var s string
+		chk.NoError(set.V(&s).WithOptions(trim).To("  hello \t"))
+		chk.Equal("hello", s)
+		chk.NoError(set.V(&s).To("  hello ")) // Off by default.
+		chk.Equal("  hello ", s)
+	}
+	{
+		type Name string
+		var n Name
+		chk.NoError(set.V(&n).WithOptions(trim).To(Name(" bob ")))
+		chk.Equal(Name("bob"), n)
+		str := " 7 "
+		var i int
+		chk.NoError(set.V(&i).WithOptions(trim).To(&str))
+		chk.Equal(7, i)
+		chk.Equal(" 7 ", str) // The source is not altered.
+	}
+	{
+		var i int
+		chk.NoError(set.V(&i).WithOptions(trim).To("123 "))
+		chk.Equal(123, i)
+		chk.NoError(set.V(&i).To("123 ")) // Numeric parsing always ignores surrounding whitespace.
+		chk.Equal(123, i)
+		var b bool
+		chk.NoError(set.V(&b).WithOptions(trim).To("\ttrue\n"))
+		chk.True(b)
+		var f float64
+		chk.NoError(set.V(&f).WithOptions(trim).To(" 1.5"))
+		chk.Equal(1.5, f)
+		var p *int
+		chk.NoError(set.V(&p).WithOptions(trim).To(" 9 "))
+		chk.Equal(9, *p)
+	}
+	{
+		var tm time.Time
+		chk.NoError(set.V(&tm).WithOptions(trim).To(" 2021-02-03T04:05:06Z "))
+		chk.Equal(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), tm)
+	}
+	{
+		var slice []int
+		chk.NoError(set.V(&slice).WithOptions(trim).To([]string{" 1", "2 ", " 3 "}))
+		chk.Equal([]int{1, 2, 3}, slice)
+	}
+	{
+		trimNulls := set.Options{TrimSpace: true, NullTokens: []string{"null"}}
+		var p *int
+		chk.NoError(set.V(&p).WithOptions(trimNulls).To(" null "))
+		chk.Nil(p)
+		var ip net.IP
+		chk.Error(set.V(&ip).To(" 10.0.0.1 "))
+		chk.NoError(set.V(&ip).WithOptions(trim).To(" 10.0.0.1 "))
+		chk.Equal("10.0.0.1", ip.String())
+	}
+	{ // Fill
+		type T struct {
+			Name    string
+			Age     int
+			Married bool
+		}
+		var dest T
+		getter := set.MapGetter(map[string]interface{}{"Name": " Bob ", "Age": " 42", "Married": "true "})
+		chk.NoError(set.V(&dest).Fill(getter))
+		chk.Equal(T{" Bob ", 42, true}, dest)
+		chk.NoError(set.V(&dest).WithOptions(trim).Fill(getter))
+		chk.Equal(T{"Bob", 42, true}, dest)
+	}
+}
+
+func TestOptions_epochUnit(t *testing.T) {
+	chk := assert.New(t)
+	//
+	when := time.Date(2021, 2, 3, 4, 5, 6, 789123456, time.UTC)
+	{ // Seconds by default.
+		var n int64
+		chk.NoError(set.V(&n).To(when))
+		chk.Equal(when.Unix(), n)
+		var f float64
+		chk.NoError(set.V(&f).To(when))
+		chk.InDelta(float64(when.Unix())+0.789123456, f, 1e-6)
+		var tm time.Time
+		chk.NoError(set.V(&tm).To(n))
+		chk.Equal(when.Truncate(time.Second), tm)
+	}
+	units := []struct {
+		Unit  time.Duration
+		Epoch int64
+	}{
+		{time.Second, 1612325106},
+		{time.Millisecond, 1612325106789},
+		{time.Microsecond, 1612325106789123},
+		{time.Nanosecond, 1612325106789123456},
+	}
+	for _, test := range units {
+		opts := set.Options{EpochUnit: test.Unit}
+		var n int64
+		chk.NoError(set.V(&n).WithOptions(opts).To(when), test.Unit)
+		chk.Equal(test.Epoch, n, test.Unit)
+		// Round trip.
+		var tm time.Time
+		chk.NoError(set.V(&tm).WithOptions(opts).To(n), test.Unit)
+		chk.Equal(when.Truncate(test.Unit), tm, test.Unit)
+		chk.Equal(time.UTC, tm.Location())
+	}
+	{ // Fractional and negative epochs.
+		millis := set.Options{EpochUnit: time.Millisecond}
+		var tm time.Time
+		chk.NoError(set.V(&tm).WithOptions(millis).To(1500.5))
+		chk.Equal(time.Unix(1, 500500000).UTC(), tm)
+		chk.NoError(set.V(&tm).WithOptions(millis).To(int64(-1500)))
+		chk.Equal(time.Unix(-2, 500000000).UTC(), tm)
+		var f float64
+		chk.NoError(set.V(&f).WithOptions(millis).To(time.Unix(1, 500500000)))
+		chk.InDelta(1500.5, f, 1e-9)
+	}
+	{ // Invalid units.
+		var n int64 = 1
+		chk.Error(set.V(&n).WithOptions(set.Options{EpochUnit: time.Minute}).To(when))
+		chk.Equal(int64(0), n)
+		var tm time.Time
+		chk.Error(set.V(&tm).WithOptions(set.Options{EpochUnit: 3 * time.Millisecond}).To(1))
+	}
+	{ // Slices.
+		var epochs []int64
+		chk.NoError(set.V(&epochs).WithOptions(set.Options{EpochUnit: time.Millisecond}).To([]time.Time{time.Unix(1, 0), time.Unix(2, 0)}))
+		chk.Equal([]int64{1000, 2000}, epochs)
+	}
+}
+
+func TestOptions_emptyAsZero(t *testing.T) {
+	chk := assert.New(t)
+	//
+	empty := set.Options{EmptyAsZero: true}
+	{
+		i := 5
+		chk.NoError(set.V(&i).WithOptions(empty).To(""))
+		chk.Equal(0, i)
+		b := true
+		chk.NoError(set.V(&b).WithOptions(empty).To("  "))
+		chk.False(b)
+		f := float32(1.5)
+		chk.NoError(set.V(&f).WithOptions(empty).To(""))
+		chk.Equal(float32(0), f)
+		u := uint8(3)
+		chk.NoError(set.V(&u).WithOptions(empty).To("\t"))
+		chk.Equal(uint8(0), u)
+		var p *int
+		chk.NoError(set.V(&p).WithOptions(empty).To(""))
+		chk.Equal(0, *p)
+	}
+	{ // Strict by default.
+		var i int
+		chk.Error(set.V(&i).To(""))
+		var b bool
+		chk.Error(set.V(&b).To(""))
+	}
+	{ // Other destinations and sources are not affected.
+		s := "x"
+		chk.NoError(set.V(&s).WithOptions(empty).To(""))
+		chk.Equal("", s)
+		var i int
+		chk.Error(set.V(&i).WithOptions(empty).To("x"))
+		var tm time.Time
+		chk.Error(set.V(&tm).WithOptions(empty).To(""))
+	}
+	{ // Fill
+		type Form struct {
+			Name  string
+			Age   int
+			Admin bool
+		}
+		form := Form{Age: 1, Admin: true}
+		getter := set.MapGetter(map[string]string{"Name": "bob", "Age": "", "Admin": ""})
+		chk.Error(set.V(&form).Fill(getter))
+		chk.NoError(set.V(&form).WithOptions(empty).Fill(getter))
+		chk.Equal(Form{Name: "bob"}, form)
+		var forms []int
+		chk.NoError(set.V(&forms).WithOptions(empty).To([]string{"1", "", "3"}))
+		chk.Equal([]int{1, 0, 3}, forms)
+	}
+}
+
+func TestOptions_clampUnsigned(t *testing.T) {
+	chk := assert.New(t)
+	//
+	clamp := set.Options{ClampUnsigned: true}
+	{ // Strict by default.
+		u := uint(5)
+		err := set.V(&u).To(-1)
+		chk.Error(err)
+		chk.Equal(uint(0), u)
+		u8 := uint8(5)
+		chk.Error(set.V(&u8).To("-1"))
+		chk.Equal(uint8(0), u8)
+		var us []uint
+		chk.Error(set.V(&us).To([]int{1, -1}))
+		chk.Nil(us)
+	}
+	{
+		u := uint(5)
+		chk.NoError(set.V(&u).WithOptions(clamp).To(-1))
+		chk.Equal(uint(0), u)
+		u16 := uint16(5)
+		chk.NoError(set.V(&u16).WithOptions(clamp).To(int64(-70000)))
+		chk.Equal(uint16(0), u16)
+		u32 := uint32(5)
+		chk.NoError(set.V(&u32).WithOptions(clamp).To(" -3.5 "))
+		chk.Equal(uint32(0), u32)
+		u64 := uint64(5)
+		chk.NoError(set.V(&u64).WithOptions(clamp).To(-0.1))
+		chk.Equal(uint64(0), u64)
+		var us []uint
+		chk.NoError(set.V(&us).WithOptions(clamp).To([]int{1, -1, 2}))
+		chk.Equal([]uint{1, 0, 2}, us)
+	}
+	{ // Non-negative values and signed destinations are unchanged.
+		var u uint
+		chk.NoError(set.V(&u).WithOptions(clamp).To("7"))
+		chk.Equal(uint(7), u)
+		chk.Error(set.V(&u).WithOptions(clamp).To("x"))
+		var i int
+		chk.NoError(set.V(&i).WithOptions(clamp).To(-1))
+		chk.Equal(-1, i)
+	}
+}
+
+func TestOptions_parseDurations(t *testing.T) {
+	chk := assert.New(t)
+	//
+	durations := set.Options{ParseDurations: true}
+	{ // Disabled by default.
+		var d time.Duration
+		chk.Error(set.V(&d).To("10s"))
+	}
+	{
+		var d time.Duration
+		chk.NoError(set.V(&d).WithOptions(durations).To("1h30m"))
+		chk.Equal(90*time.Minute, d)
+		chk.NoError(set.V(&d).WithOptions(durations).To(" 250ms "))
+		chk.Equal(250*time.Millisecond, d)
+		chk.NoError(set.V(&d).WithOptions(durations).To("42"))
+		chk.Equal(time.Duration(42), d)
+		var ds []time.Duration
+		chk.NoError(set.V(&ds).WithOptions(durations).To([]string{"1s", "2m"}))
+		chk.Equal([]time.Duration{time.Second, 2 * time.Minute}, ds)
+		var p *time.Duration
+		chk.NoError(set.V(&p).WithOptions(durations).To("3s"))
+		chk.Equal(3*time.Second, *p)
+	}
+	{ // Errors.
+		d := time.Second
+		err := set.V(&d).WithOptions(durations).To("10 parsecs")
+		chk.Error(err)
+		chk.Equal(time.Duration(0), d)
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+	}
+	{ // Sizes are not parsed into durations and durations are not parsed into other integers.
+		var d time.Duration
+		chk.Error(set.V(&d).WithOptions(set.Options{ParseByteSizes: true}).To("10MB"))
+		var n int64
+		chk.Error(set.V(&n).WithOptions(durations).To("10s"))
+	}
+}
+
+func TestOptions_parseByteSizes(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sizes := set.Options{ParseByteSizes: true}
+	{ // Disabled by default.
+		var n int
+		chk.Error(set.V(&n).To("10MB"))
+	}
+	{
+		var n int64
+		chk.NoError(set.V(&n).WithOptions(sizes).To("10MB"))
+		chk.Equal(int64(10000000), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To("2GiB"))
+		chk.Equal(int64(2<<30), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To("512"))
+		chk.Equal(int64(512), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To("512B"))
+		chk.Equal(int64(512), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To(" 1.5 kib "))
+		chk.Equal(int64(1536), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To("3kB"))
+		chk.Equal(int64(3000), n)
+		chk.NoError(set.V(&n).WithOptions(sizes).To("-1KiB"))
+		chk.Equal(int64(-1024), n)
+		var u uint64
+		chk.NoError(set.V(&u).WithOptions(sizes).To("15EiB"))
+		chk.Equal(uint64(15)<<60, u)
+		var us []uint32
+		chk.NoError(set.V(&us).WithOptions(sizes).To([]string{"1KB", "1KiB"}))
+		chk.Equal([]uint32{1000, 1024}, us)
+	}
+	{ // Humanized numbers.
+		var n int
+		chk.NoError(set.V(&n).WithOptions(set.Options{ParseByteSizes: true, Humanize: true}).To("1,024 MB"))
+		chk.Equal(1024000000, n)
+	}
+	{ // Errors.
+		n := 7
+		err := set.V(&n).WithOptions(sizes).To("10XB")
+		chk.Error(err)
+		chk.Contains(err.Error(), `unknown size suffix "XB"`)
+		chk.Equal(0, n)
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		err = set.V(&n).WithOptions(sizes).To("1.5B")
+		chk.Error(err)
+		chk.Contains(err.Error(), "whole number")
+		chk.Error(set.V(&n).WithOptions(sizes).To("MB"))
+		chk.Error(set.V(&n).WithOptions(sizes).To("1.2.3MB"))
+		chk.Error(set.V(&n).WithOptions(sizes).To("16EiB"))
+		var u uint
+		chk.Error(set.V(&u).WithOptions(sizes).To("-1KB"))
+	}
+	{ // Sizes that do not fit the destination.
+		u16 := uint16(7)
+		err := set.V(&u16).WithOptions(sizes).To("1MB")
+		chk.Error(err)
+		chk.Contains(err.Error(), "overflows uint16")
+		chk.Equal(uint16(0), u16)
+		var coerceErr *set.CoerceError
+		chk.True(stderrors.As(err, &coerceErr))
+		chk.NoError(set.V(&u16).WithOptions(sizes).To("63KiB"))
+		chk.Equal(uint16(63*1024), u16)
+		u32 := uint32(7)
+		err = set.V(&u32).WithOptions(sizes).To("8GiB")
+		chk.Error(err)
+		chk.Contains(err.Error(), "overflows uint32")
+		chk.Equal(uint32(0), u32)
+		chk.NoError(set.V(&u32).WithOptions(sizes).To("4GB"))
+		chk.Equal(uint32(4000000000), u32)
+		var i16 int16
+		chk.Error(set.V(&i16).WithOptions(sizes).To("1MB"))
+	}
+	{ // Floats and strings are not affected.
+		var f float64
+		chk.Error(set.V(&f).WithOptions(sizes).To("10MB"))
+		var s string
+		chk.NoError(set.V(&s).WithOptions(sizes).To("10MB"))
+		chk.Equal("10MB", s)
+	}
+}
@@ -255,6 +255,27 @@
 // 	var t Person
 // 	set.V(&t).FillByTag("key", myGetter)
 //
+// Assembling Struct Types from a Getter
+//
+// Some struct types, such as time.Time, can not be sub-filled because their fields are unexported.  When a
+// Getter returns a Getter for a field whose type has a registered Assembler then the Assembler creates the
+// value from the nested Getter.  An Assembler for time.Time is registered by default and accepts the keys
+// year, month, day, hour, minute, second, and nanosecond:
+// 	m := map[string]interface{}{
+// 		"Born": map[string]interface{}{
+// 			"year":  1970,
+// 			"month": "3",
+// 			"day":   21,
+// 		},
+// 	}
+// 	type Person struct {
+// 		Born time.Time
+// 	}
+// 	var t Person
+// 	set.V(&t).Fill(set.MapGetter(m))
+//
+// See RegisterAssembler() to register an Assembler for your own types.
+//
 // Populating Structs with Mapper, Mapping, and BoundMap
 //
 // If you need to populate or traverse structs using strings as lookups consider using a Mapper.  A Mapper traverses a type T
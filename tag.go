@@ -0,0 +1,65 @@
+package set
+
+import (
+	"strings"
+)
+
+// TagOptions is the structured form of a struct-tag value; see ParseTag().
+type TagOptions struct {
+	// Name is the name in the tag.
+	Name string
+	// Flags are the parts of the tag without an equal sign, excluding the name, in the order they appear.
+	Flags []string
+	// Options are the key=value parts of the tag, excluding name=value.
+	Options map[string]string
+}
+
+// ParseTag parses a struct-tag value into its name, flags, and key=value options.  Parts are separated by commas
+// and surrounding whitespace is ignored.  The name is the first part if it does not contain an equal sign;
+// a name=value part sets the name explicitly.  Other key=value parts become options and all remaining
+// parts become flags:
+//	ParseTag("user_id,omitempty")			// Name: "user_id", Flags: [omitempty]
+//	ParseTag("name=user_id,required,default=0")	// Name: "user_id", Flags: [required], Options: {default: 0}
+//	ParseTag(",required")				// Name: "", Flags: [required]
+//
+// Only the first equal sign in a part separates the key from the value so values may contain equal signs
+// but they can not contain commas.
+func ParseTag(tag string) TagOptions {
+	var rv TagOptions
+	for k, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if eq := strings.Index(part, "="); eq != -1 {
+			key, value := strings.TrimSpace(part[:eq]), strings.TrimSpace(part[eq+1:])
+			if key == "name" {
+				rv.Name = value
+				continue
+			}
+			if rv.Options == nil {
+				rv.Options = map[string]string{}
+			}
+			rv.Options[key] = value
+		} else if k == 0 {
+			rv.Name = part
+		} else if part != "" {
+			rv.Flags = append(rv.Flags, part)
+		}
+	}
+	return rv
+}
+
+// Has returns true if flag is one of the flags.
+func (me TagOptions) Has(flag string) bool {
+	for _, f := range me.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Option returns the value of the key=value option; the second return value is false if the option
+// does not exist.
+func (me TagOptions) Option(key string) (string, bool) {
+	value, ok := me.Options[key]
+	return value, ok
+}
@@ -0,0 +1,38 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestParseTag(t *testing.T) {
+	chk := assert.New(t)
+	//
+	for _, test := range []struct {
+		Tag    string
+		Expect set.TagOptions
+	}{
+		{"", set.TagOptions{}},
+		{"id", set.TagOptions{Name: "id"}},
+		{"id,omitempty", set.TagOptions{Name: "id", Flags: []string{"omitempty"}}},
+		{",required", set.TagOptions{Flags: []string{"required"}}},
+		{"name=user_id,required,default=0", set.TagOptions{Name: "user_id", Flags: []string{"required"}, Options: map[string]string{"default": "0"}}},
+		{"default=a=b, name = x , flag ,,", set.TagOptions{Name: "x", Flags: []string{"flag"}, Options: map[string]string{"default": "a=b"}}},
+		{"default=", set.TagOptions{Options: map[string]string{"default": ""}}},
+	} {
+		chk.Equal(test.Expect, set.ParseTag(test.Tag), test.Tag)
+	}
+	{
+		options := set.ParseTag("id,required,default=5")
+		chk.True(options.Has("required"))
+		chk.False(options.Has("omitempty"))
+		value, ok := options.Option("default")
+		chk.True(ok)
+		chk.Equal("5", value)
+		_, ok = options.Option("missing")
+		chk.False(ok)
+	}
+}
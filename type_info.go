@@ -19,6 +19,9 @@ type TypeInfo struct {
 	// True if the Value is a slice.
 	IsSlice bool
 
+	// True if the Value is an array.
+	IsArray bool
+
 	// True if the Value is a struct.
 	IsStruct bool
 
@@ -30,8 +33,8 @@ type TypeInfo struct {
 	// type at the end of the pointer chain.  Otherwise it will be the original type.
 	Type reflect.Type
 
-	// When IsMap or IsSlice are true then ElemType will be the reflect.Type for elements that can be directly
-	// inserted into the map or slice; it is not the type at the end of the chain if the element type is a pointer.
+	// When IsMap, IsSlice, or IsArray are true then ElemType will be the reflect.Type for elements that can be directly
+	// inserted into the map, slice, or array; it is not the type at the end of the chain if the element type is a pointer.
 	ElemType reflect.Type
 
 	// When IsStruct is true then StructFields will contain the reflect.StructField values for the struct.
@@ -105,13 +108,14 @@ func (me *typeInfoCache) StatType(T reflect.Type) TypeInfo {
 	//
 	rv.IsMap = K == reflect.Map
 	rv.IsSlice = K == reflect.Slice
+	rv.IsArray = K == reflect.Array
 	rv.IsStruct = K == reflect.Struct
 	rv.IsScalar = K == reflect.Bool ||
 		K == reflect.Int || K == reflect.Int8 || K == reflect.Int16 || K == reflect.Int32 || K == reflect.Int64 ||
 		K == reflect.Uint || K == reflect.Uint8 || K == reflect.Uint16 || K == reflect.Uint32 || K == reflect.Uint64 ||
 		K == reflect.Float32 || K == reflect.Float64 ||
 		K == reflect.String
-	if rv.IsMap || rv.IsSlice {
+	if rv.IsMap || rv.IsSlice || rv.IsArray {
 		rv.ElemType = T.Elem()
 	} else if rv.IsStruct {
 		for k, size := 0, T.NumField(); k < size; k++ {
@@ -0,0 +1,28 @@
+package set
+
+import (
+	"reflect"
+)
+
+// Validator is implemented by structs that enforce their own invariants.
+//
+// When Fill() or its variants finish populating a struct that implements Validator, with either a value or
+// pointer receiver, Validate() is called and its error is returned.  Nested structs are filled, and therefore
+// validated, before the struct that contains them.
+type Validator interface {
+	// Validate returns an error if the struct is not valid.
+	Validate() error
+}
+
+// validate calls Validate() if v, or a pointer to v, implements Validator.
+func validate(v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	} else if v.CanAddr() {
+		v = v.Addr()
+	}
+	if validator, ok := v.Interface().(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
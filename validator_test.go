@@ -0,0 +1,82 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/nofeaturesonlybugs/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// validated records the order in which Validate() is called.
+var validated []string
+
+type validatedAddress struct {
+	City string
+}
+
+func (a validatedAddress) Validate() error {
+	validated = append(validated, "address")
+	if a.City == "" {
+		return errors.Errorf("city is required")
+	}
+	return nil
+}
+
+type validatedPerson struct {
+	Name    string
+	Address validatedAddress
+}
+
+func (p *validatedPerson) Validate() error {
+	validated = append(validated, "person")
+	if p.Name == "" {
+		return errors.Errorf("name is required")
+	}
+	return nil
+}
+
+func TestValidator(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // Nested validators are called first.
+		validated = nil
+		var p validatedPerson
+		chk.NoError(set.V(&p).Fill(set.MapGetter(map[string]interface{}{
+			"Name":    "bob",
+			"Address": map[string]interface{}{"City": "Paris"},
+		})))
+		chk.Equal([]string{"address", "person"}, validated)
+	}
+	{
+		validated = nil
+		var p validatedPerson
+		err := set.V(&p).FillByTag("json", set.MapGetter(map[string]interface{}{}))
+		chk.Error(err)
+		chk.Equal("name is required", err.Error())
+		chk.Equal([]string{"person"}, validated) // Address has no json tag and is not filled.
+	}
+	{
+		validated = nil
+		var p validatedPerson
+		err := set.V(&p).Fill(set.MapGetter(map[string]interface{}{
+			"Name":    "bob",
+			"Address": map[string]interface{}{},
+		}))
+		chk.Error(err)
+		chk.Equal("city is required", err.Error())
+		chk.Equal([]string{"address"}, validated)
+	}
+	{ // Slices of validators.
+		validated = nil
+		var people []validatedPerson
+		err := set.V(&people).FillAll([]set.Getter{
+			set.MapGetter(map[string]interface{}{"Name": "a", "Address": map[string]interface{}{"City": "x"}}),
+			set.MapGetter(map[string]interface{}{"Address": map[string]interface{}{"City": "y"}}),
+		})
+		chk.Error(err)
+		chk.Contains(err.Error(), "row 1")
+		chk.Nil(people)
+	}
+}
@@ -1,8 +1,14 @@
 package set
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nofeaturesonlybugs/errors"
 )
@@ -39,7 +45,7 @@ func V(arg interface{}) *Value {
 	rv.WriteValue, rv.CanWrite = Writable(v)
 	rv.TopValue = v
 
-	if rv.IsMap || rv.IsSlice {
+	if rv.IsMap || rv.IsSlice || rv.IsArray {
 		rv.ElemTypeInfo = TypeCache.StatType(rv.ElemType)
 	}
 	return rv
@@ -57,7 +63,9 @@ type Value struct {
 	// Treat this value as read only.
 	TypeInfo
 
-	// CanWrite specifies if WriteValue.CanSet() would return true.
+	// CanWrite specifies if WriteValue.CanSet() would return true; it is the equivalent of reflect.Value.CanSet()
+	// and methods that alter the value, such as To() and Fill(), return an error when it is false.  See also
+	// CanAddr().
 	CanWrite bool
 
 	// TopValue is the original value passed to V() but wrapped in a reflect.Value.
@@ -73,11 +81,17 @@ type Value struct {
 	// value.  Generally you should avoid it but it's also present if you really know what you're doing.
 	WriteValue reflect.Value
 
-	// When IsMap or IsSlice are true then ElemTypeInfo is a TypeInfo struct describing the element types.
+	// When IsMap, IsSlice, or IsArray are true then ElemTypeInfo is a TypeInfo struct describing the element types.
 	ElemTypeInfo TypeInfo
 
 	//
 	original interface{}
+	options  *Options
+	// context prefixes the errors returned by To(), Append(), and the Fill methods; see WithContext().
+	context string
+	// nilled is true when a pointer leading to WriteValue was set to nil by To(); the pointer is
+	// instantiated again on the next call to To().
+	nilled bool
 }
 
 // errorUnsupported returns a string that can be used in an error message to indicate the underlying original type
@@ -86,13 +100,39 @@ func (me *Value) errorUnsupported(method string) string {
 	return fmt.Sprintf("%v is unsupported for original type [%T]", method, me.original)
 }
 
+// Addr returns a *Value wrapped around the address of the value wrapped by Value; an error is returned if the
+// value is not addressable, which is the case when V() was not called with a pointer.  Changes made through
+// the returned *Value are seen in the original.
+func (me *Value) Addr() (*Value, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if !me.WriteValue.IsValid() || !me.WriteValue.CanAddr() {
+		return nil, errors.Errorf(me.errorUnsupported("Addr"))
+	}
+	return me.v(me.WriteValue.Addr()), nil
+}
+
 // Append appends the item(s) to the end of the Value assuming it is some type of slice and every
 // item can be type-coerced into the slice's data type.  Either all items are appended without an error
 // or no items are appended and an error is returned describing the type of the item that could not
 // be appended.
+//
+// If Value is a map then Append upserts entries; items must be a single map, whose keys and elements are coerced
+// into the map's types, or an even number of items that are key-value pairs.  A nil map is created as needed
+// and either all entries are set or none are:
+//	m := map[string]int{"a": 1}
+//	set.V(&m).Append(map[string]string{"a": "10", "b": "2"})	// m is map[a:10 b:2]
+//	set.V(&m).Append("c", 3.0, "d", "4")				// m is map[a:10 b:2 c:3 d:4]
 func (me *Value) Append(items ...interface{}) error {
+	return me.contextError(me.appendItems(items))
+}
+
+// appendItems is the implementation of Append().
+func (me *Value) appendItems(items []interface{}) error {
 	if me == nil {
 		return errors.NilReceiver()
+	} else if me.Kind == reflect.Map {
+		return me.appendMap(items)
 	} else if me.Kind != reflect.Slice {
 		return errors.Errorf(me.errorUnsupported("Append"))
 	}
@@ -106,7 +146,7 @@ func (me *Value) Append(items ...interface{}) error {
 		zero := reflect.Zero(me.Type)
 		for _, item := range items {
 			elem := reflect.New(me.ElemType)
-			elemAsValue := V(elem)
+			elemAsValue := me.v(elem)
 			if err = elemAsValue.To(item); err != nil {
 				err = errors.Go(err)
 				return
@@ -118,6 +158,113 @@ func (me *Value) Append(items ...interface{}) error {
 	return err
 }
 
+// appendMap is the implementation of Append() for maps.
+func (me *Value) appendMap(items []interface{}) error {
+	if !me.CanWrite {
+		return errors.Errorf(me.errorUnsupported("Append"))
+	}
+	entries := reflect.New(me.Type)
+	if len(items) == 1 && reflect.ValueOf(items[0]).Kind() == reflect.Map {
+		if err := me.v(entries).To(items[0]); err != nil {
+			return errors.Go(err)
+		}
+	} else if len(items)%2 != 0 {
+		return errors.Errorf("Append to %v expects a map or key-value pairs; got %v items", me.Type, len(items))
+	} else {
+		entries.Elem().Set(reflect.MakeMapWithSize(me.Type, len(items)/2))
+		for k := 0; k < len(items); k += 2 {
+			key, elem := reflect.New(me.Type.Key()), me.v(reflect.New(me.ElemType))
+			if err := me.v(key).To(items[k]); err != nil {
+				return errors.Errorf("While coercing map key [%v]: %v", items[k], err.Error())
+			} else if err = elem.To(items[k+1]); err != nil {
+				return errors.Errorf("While coercing map element [%v]: %v", items[k], err.Error())
+			}
+			entries.Elem().SetMapIndex(key.Elem(), reflect.Indirect(elem.TopValue))
+		}
+	}
+	me.mergeMap(entries.Elem())
+	return nil
+}
+
+// mergeMap sets the entries of the map m, which is the same type as Value, into the map wrapped by Value; the
+// wrapped map is created if it is nil.
+func (me *Value) mergeMap(m reflect.Value) {
+	if m.Len() == 0 {
+		return
+	} else if me.WriteValue.IsNil() {
+		me.WriteValue.Set(reflect.MakeMapWithSize(me.Type, m.Len()))
+	}
+	iter := m.MapRange()
+	for iter.Next() {
+		me.WriteValue.SetMapIndex(iter.Key(), iter.Value())
+	}
+}
+
+// AppendReflect is the same as Append() except the items are reflect.Values; items whose type is the slice's
+// element type are appended directly without first converting them to an interface{}.  An invalid
+// reflect.Value appends the element type's zero value.
+//
+// Either all items are appended or none are; if an item can not be coerced then an ElemError describing its
+// index in items is returned.
+func (me *Value) AppendReflect(items ...reflect.Value) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if me.Kind != reflect.Slice || !me.CanWrite {
+		return errors.Errorf(me.errorUnsupported("AppendReflect"))
+	}
+	direct := me.ElemTypeInfo.Kind != reflect.Slice && !isEnum(me.ElemType)
+	appended := reflect.MakeSlice(me.Type, 0, len(items))
+	for k, item := range items {
+		if item.IsValid() && !item.CanInterface() {
+			return ElemError{Index: k, Err: errors.Errorf("AppendReflect can not read the value of an unexported field")}
+		} else if direct && item.IsValid() && item.Type() == me.ElemType {
+			appended = reflect.Append(appended, item)
+			continue
+		}
+		elemAsValue := me.v(reflect.New(me.ElemType))
+		var arg interface{}
+		if item.IsValid() {
+			arg = item.Interface()
+		}
+		if err := elemAsValue.To(arg); err != nil {
+			return ElemError{Index: k, Err: err}
+		}
+		appended = reflect.Append(appended, reflect.Indirect(elemAsValue.TopValue))
+	}
+	me.WriteValue.Set(reflect.AppendSlice(me.WriteValue, appended))
+	return nil
+}
+
+// CanAddr returns true if the value wrapped by Value is addressable, which is required by Addr(); it is
+// false for a nil receiver or when V() was not called with a pointer.
+func (me *Value) CanAddr() bool {
+	return me != nil && me.WriteValue.IsValid() && me.WriteValue.CanAddr()
+}
+
+// Convert returns a new *Value wrapped around a copy of the value converted to type T; an error is returned if
+// the value can not be converted according to reflect.Type.ConvertibleTo().  Unlike To(), which assigns into
+// the existing value, Convert leaves Value unchanged and the returned *Value is writable.
+//	type Celsius float64
+//	f := 21.5
+//	c, err := set.V(f).Convert(reflect.TypeOf(Celsius(0))) // c.WriteValue.Interface() is Celsius(21.5)
+func (me *Value) Convert(T reflect.Type) (rv *Value, err error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if !me.WriteValue.IsValid() || T == nil {
+		return nil, errors.Errorf(me.errorUnsupported("Convert"))
+	} else if !me.WriteValue.Type().ConvertibleTo(T) {
+		return nil, errors.Errorf("Convert can not convert %v to %v", me.WriteValue.Type(), T)
+	}
+	defer func() {
+		if r := recover(); r != nil { // e.g. a slice converted to an array pointer with too few elements
+			rv, err = nil, errors.Errorf("Convert %v to %v: %v", me.WriteValue.Type(), T, r)
+		}
+	}()
+	ptr := reflect.New(T)
+	ptr.Elem().Set(me.WriteValue.Convert(T))
+	return me.v(ptr), nil
+}
+
 // Copy creates a clone of the *Value and its internal members.
 //
 // If you need to create many *Value for a type T in order to Rebind(T) in a goroutine
@@ -131,12 +278,41 @@ func (me *Value) Copy() *Value {
 		WriteValue:   me.WriteValue,
 		ElemTypeInfo: me.ElemTypeInfo,
 		original:     me.original,
+		options:      me.options,
+		context:      me.context,
+		nilled:       me.nilled,
+	}
+	return rv
+}
+
+// WithContext returns a copy of Value whose To(), ToAppend(), Append(), and Fill methods prefix the errors
+// they return with prefix; calling WithContext on such a copy joins the prefixes.  The returned errors are
+// of type *ContextError and the original error is still available with errors.As() or errors.Unwrap().
+//	err := set.V(&port).WithContext("config.port").To("http")
+//	// err.Error() is "config.port: Coercing string into int: ..."
+func (me *Value) WithContext(prefix string) *Value {
+	if me == nil {
+		return nil
+	}
+	rv := me.Copy()
+	if rv.context != "" && prefix != "" {
+		rv.context += ": " + prefix
+	} else if prefix != "" {
+		rv.context = prefix
 	}
 	return rv
 }
 
+// contextError wraps err in a *ContextError when Value has a context; otherwise err is returned as-is.
+func (me *Value) contextError(err error) error {
+	if err == nil || me == nil || me.context == "" {
+		return err
+	}
+	return &ContextError{Context: me.context, Err: err}
+}
+
 // Fields returns a slice of Field structs when Value is wrapped around a struct; for all other values
-// nil is returned.
+// nil is returned.  Fields are always returned in declaration order.
 //
 // This function has some overhead because it creates a new *Value for each struct field.  If you only need
 // the reflect.StructField information consider using the public StructFields member.
@@ -148,7 +324,7 @@ func (me *Value) Fields() []Field {
 	if me != nil && me.IsStruct {
 		for k, max := 0, me.Type.NumField(); k < max; k++ {
 			v, f := me.WriteValue.Field(k), me.Type.Field(k)
-			rv = append(rv, Field{Value: V(v), Field: f})
+			rv = append(rv, Field{Value: me.v(v), Field: f})
 		}
 	}
 	return rv
@@ -174,7 +350,7 @@ func (me *Value) FieldByIndex(index []int) (reflect.Value, error) {
 		n := index[k] // n is the index (or field num) to consider
 		if v.Kind() != reflect.Struct {
 			return v, errors.Errorf("FieldByIndex requires type to be a struct; type is %v", v.Type())
-		} else if n > v.NumField() {
+		} else if n < 0 || n >= v.NumField() {
 			return v, errors.Errorf("Index out of bounds; field is len %v and index is %v", v.NumField(), n)
 		}
 		v = v.Field(n)
@@ -203,11 +379,82 @@ func (me *Value) FieldByIndexAsValue(index []int) (*Value, error) {
 	if v, err = me.FieldByIndex(index); err != nil {
 		return nil, errors.Go(err)
 	}
-	return V(v), nil
+	return me.v(v), nil
+}
+
+// FieldsFlattened is the same as Fields() except the fields of embedded structs, or pointers to embedded structs,
+// are promoted into the returned slice and the embedded fields themselves are omitted.  Field promotion
+// follows the same rules as the Go language: a field at a shallower depth shadows fields of the same name
+// at deeper depths and fields of the same name at the same depth are ambiguous and omitted.
+//
+// The Index member of each returned Field.Field is the full index path from this Value to the field; i.e.
+// it can be passed to FieldByIndex().
+//
+// Fields are returned depth-first in declaration order; i.e. the fields promoted from an embedded struct
+// appear, in their own declaration order, at the position the embedded struct is declared.
+//
+// If the Value is writable then nil pointers to embedded structs are instantiated; otherwise fields
+// promoted through nil pointers are not returned.
+func (me *Value) FieldsFlattened() []Field {
+	if me == nil || me.Kind != reflect.Struct {
+		return nil
+	}
+	var rv []Field
+	for _, f := range flattenFields(me.Type) {
+		if v, ok := fieldByIndexPath(me.WriteValue, f.Index); ok {
+			rv = append(rv, Field{Value: me.v(v), Field: f})
+		}
+	}
+	return rv
+}
+
+// DeepFields returns the exported fields of the struct wrapped by Value as a flat list; fields that are
+// structs, or pointers to structs, are replaced by their own fields with the field name and a period prepended
+// to their Path.  Fields of embedded structs are promoted as in FieldsFlattened() and have no prefix.  Slices,
+// maps, and atomic types such as time.Time are not descended into; neither is a struct type that contains
+// itself, which is returned as a field.
+//	type Address struct {
+//		City string
+//	}
+//	type T struct {
+//		Name    string
+//		Address *Address
+//		Tags    []string
+//	}
+//	set.V(&t).DeepFields() // Paths are "Name", "Address.City", and "Tags".
+//
+// Like Fields(), nil pointers are instantiated if the Value is writable; otherwise structs behind nil pointers
+// are returned as fields.
+func (me *Value) DeepFields() []FlatField {
+	if me == nil || me.Kind != reflect.Struct {
+		return nil
+	}
+	return me.deepFields("", nil, map[reflect.Type]bool{})
+}
+
+// deepFields appends the fields for DeepFields() to rv with prefix prepended to their paths; visiting contains
+// the struct types being descended into.
+func (me *Value) deepFields(prefix string, rv []FlatField, visiting map[reflect.Type]bool) []FlatField {
+	visiting[me.Type] = true
+	defer delete(visiting, me.Type)
+	for _, field := range me.FieldsFlattened() {
+		if field.Field.PkgPath != "" {
+			continue
+		}
+		path := prefix + field.Field.Name
+		if value := field.Value; value.IsStruct && !isAtomic(value.Type) && !visiting[value.Type] && value.WriteValue.IsValid() {
+			rv = value.deepFields(path+".", rv, visiting)
+			continue
+		}
+		rv = append(rv, FlatField{Path: path, Field: field.Field, Value: field.Value})
+	}
+	return rv
 }
 
 // FieldsByTag is the same as Fields() except only Fields with the given struct-tag are returned and the
-// TagValue member of Field will be set to the tag's value.
+// TagValue member of Field will be set to the tag's value.  Fields are returned in declaration order.
+//
+// Like encoding/json, fields whose tag value is exactly "-" are omitted.
 func (me *Value) FieldsByTag(key string) []Field {
 	if me == nil || me.Kind != reflect.Struct {
 		return nil
@@ -215,7 +462,7 @@ func (me *Value) FieldsByTag(key string) []Field {
 	var rv []Field
 	all := me.Fields()
 	for _, f := range all {
-		if value, ok := f.Field.Tag.Lookup(key); ok {
+		if value, ok := f.Field.Tag.Lookup(key); ok && value != "-" {
 			f.TagValue = value
 			rv = append(rv, f)
 		}
@@ -223,6 +470,39 @@ func (me *Value) FieldsByTag(key string) []Field {
 	return rv
 }
 
+// FieldsByTags is the same as FieldsByTag() except the struct-tags of every key are read in a single pass;
+// Fields with at least one of the struct-tags are returned and their TagValues member maps each key present
+// to its value.  TagValue is set to the value of the first key present.
+//
+// Like FieldsByTag(), tag values that are exactly "-" are omitted.
+//	type T struct {
+//		A string `db:"a" json:"json_a"`	// TagValues is map[db:a json:json_a]
+//		B string `json:"b,omitempty"`	// TagValues is map[json:b,omitempty]
+//		C string				// Omitted.
+//	}
+//	fields := set.V(&t).FieldsByTags("db", "json")
+func (me *Value) FieldsByTags(keys ...string) []Field {
+	if me == nil || me.Kind != reflect.Struct {
+		return nil
+	}
+	var rv []Field
+	for _, f := range me.Fields() {
+		for _, key := range keys {
+			if value, ok := f.Field.Tag.Lookup(key); ok && value != "-" {
+				if f.TagValues == nil {
+					f.TagValues = map[string]string{}
+					f.TagValue = value
+				}
+				f.TagValues[key] = value
+			}
+		}
+		if f.TagValues != nil {
+			rv = append(rv, f)
+		}
+	}
+	return rv
+}
+
 // fill is the underlying function that powers Fill() and FillByTag().
 //
 // getter is the original Getter passed to Fill() or FillByTag().
@@ -230,28 +510,85 @@ func (me *Value) FieldsByTag(key string) []Field {
 // Fill() and FillByTag() have essentially the same complicated logic except where they get the string/key to pass
 // to getter() and how they sub-fill nested structures.  The keyFunc and fillFunc arguments allow them to
 // cascade the appropriate logic into this function.
-func (me *Value) fill(getter Getter, fields []Field, keyFunc func(Field) string, fillFunc func(*Value, Getter) error) error {
+//
+// keyFunc also returns the TagOptions for the field; when getter returns nil for a field the required flag
+// and default option are honored.  Fields for which keyFunc returns an empty name, such as fields tagged "-",
+// are not filled.
+func (me *Value) fill(getter Getter, fields []Field, keyFunc func(Field) (string, TagOptions), fillFunc func(*Value, Getter, *fillConfig) error, cfg *fillConfig) error {
 	var err error
+	var nested *fillConfig
 	for _, field := range fields {
-		getName := keyFunc(field)
-		switch got := getter.Get(getName).(type) {
+		if field.Field.PkgPath != "" {
+			continue // Unexported fields can not be set.
+		} else if isUnfillableKind(field.Value.Kind) {
+			continue
+		}
+		getName, tagOptions := keyFunc(field)
+		if getName == "" {
+			continue
+		}
+		value := getter.Get(getName)
+		if value == nil {
+			if tagOptions.Has("required") {
+				return errors.Errorf("Getter.Get( %v ) returned nil for required field %v", getName, field.Field.Name)
+			} else if def, ok := tagOptions.Option("default"); ok {
+				value = def
+			}
+		}
+		value = mapsAsGetters(field, value)
+		before, compare := cfg.snapshot(field, value)
+		fieldCfg := cfg
+		if compare {
+			fieldCfg = cfg.whole() // The elements of slices and maps are not compared or reported individually.
+		}
+		switch got := value.(type) {
 
 		case Getter:
 			// What was returned from the Getter is itself a Getter; therefore we expect field.Value
-			// to be either a struct or []struct that we can sub-fill.
-			if field.Value.IsStruct {
-				if err = fillFunc(field.Value, got); err != nil {
+			// to be either a type with a registered Assembler or a struct or []struct that we can sub-fill.
+			if assemble, ok := assemblerFor(field.Value.Type); ok {
+				var assembled interface{}
+				if assembled, err = assemble(got); err != nil {
+					return errors.Errorf("While assembling field %v: %v", field.Field.Name, err.Error())
+				}
+				if err = field.Value.To(assembled); err != nil {
+					return errors.Go(err)
+				}
+			} else if isAtomic(field.Value.Type) {
+				return errors.Errorf("Getter.Get( %v ) returned a Getter for field %v and atomic type %v can not be sub-filled.", getName, field.Field.Name, field.Value.Type)
+			} else if field.Value.IsStruct {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				} else if err = fillFunc(field.Value, got, nested); err != nil {
 					return errors.Go(err)
 				}
 			} else if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
-				if err = field.Value.Zero(); err != nil {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				} else if err = field.Value.Zero(); err != nil {
 					return errors.Go(err)
 				}
-				elem := V(reflect.New(field.Value.ElemTypeInfo.Type))
-				if err = fillFunc(elem, got); err != nil {
+				elem := me.v(reflect.New(field.Value.ElemTypeInfo.Type))
+				if err = fillFunc(elem, got, nested); err != nil {
 					return errors.Go(err)
 				}
 				field.Value.Append(elem.WriteValue.Interface()) // This can return an error but it _should_be_ impossible.
+			} else if field.Value.IsMap {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				} else if err = me.fillMapFromGetter(field.Value, got, fillFunc, nested); err != nil {
+					return errors.Errorf("While filling map field %v: %v", field.Field.Name, err.Error())
+				}
+			} else if field.Value.Kind == reflect.Interface {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				} else if err = me.fillInterface(field, getName, got, fillFunc, nested); err != nil {
+					return errors.Go(err)
+				}
+			} else if scalar, ok := cfg.scalar(got); ok {
+				if err = field.Value.To(scalar); err != nil {
+					return errors.Go(err)
+				}
 			} else {
 				return errors.Errorf("Getter.Get( %v ) returned a Getter for field %v and field is not fillable.", getName, field.Field.Name)
 			}
@@ -260,13 +597,16 @@ func (me *Value) fill(getter Getter, fields []Field, keyFunc func(Field) string,
 			// What was returned from the Getter is a []Getter; therefore we expect field.Value to
 			// be a []struct or struct that we can sub-fill.
 			if field.Value.IsSlice && field.Value.ElemTypeInfo.IsStruct {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				}
 				// Zero out the existing slice.
 				if err = field.Value.Zero(); err != nil {
 					return errors.Go(err)
 				}
 				for _, elemGetter := range got {
-					elem := V(reflect.New(field.Value.ElemTypeInfo.Type))
-					if err = fillFunc(elem, elemGetter); err != nil {
+					elem := me.v(reflect.New(field.Value.ElemTypeInfo.Type))
+					if err = fillFunc(elem, elemGetter, nested); err != nil {
 						return errors.Go(err)
 					}
 					field.Value.Append(elem.WriteValue.Interface()) // This can return an error but it _should_be impossible.
@@ -274,46 +614,779 @@ func (me *Value) fill(getter Getter, fields []Field, keyFunc func(Field) string,
 			} else if field.Value.IsStruct {
 				size := len(got)
 				if size > 0 {
-					if err = fillFunc(field.Value, got[size-1]); err != nil {
+					if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+						return errors.Go(err)
+					} else if err = fillFunc(field.Value, got[size-1], nested); err != nil {
 						return errors.Go(err)
 					}
 				}
+			} else if field.Value.IsMap && field.Value.ElemTypeInfo.IsStruct {
+				if nested, err = fieldCfg.nested(field.Field.Name); err != nil {
+					return errors.Go(err)
+				} else if err = me.fillMap(field, got, fillFunc, nested); err != nil {
+					return errors.Go(err)
+				}
+			} else if field.Value.Kind == reflect.Interface {
+				return errors.Errorf("Getter.Get( %v ) returned a []Getter for field %v of interface type %v and field is not fillable.", getName, field.Field.Name, field.Value.Type)
 			} else {
 				return errors.Errorf("Getter.Get( %v ) returned a []Getter for field %v and field is not fillable.", getName, field.Field.Name)
 			}
 
 		default:
-			if err = field.Value.To(got); err != nil {
+			if layout, ok := timeLayout(field); ok {
+				if value, err = parseTimeLayout(layout, got); err != nil {
+					return errors.Errorf("While parsing field %v with layout %v: %v", field.Field.Name, layout, err.Error())
+				}
+			}
+			if err = field.Value.To(cfg.split(field, value)); err != nil {
 				return errors.Go(err)
 			}
 		}
+		if compare {
+			cfg.compare(field, before)
+		}
 	}
+	return errors.Go(validate(me.WriteValue))
+}
+
+// mapsAsGetters returns value as a Getter when it is a map with string or interface{} keys and field is a struct
+// or as a []Getter when it is a slice of such maps and field is a slice of structs; otherwise value is returned
+// unchanged.  A Getter may return decoded data, such as JSON objects, as maps rather than Getters; converting
+// them lets fill() sub-fill the field with the same fill function and configuration, including MaxDepth(), instead
+// of coercing the map with To().
+func mapsAsGetters(field Field, value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return value
+	} else if _, ok := value.(Getter); ok {
+		return value
+	} else if v.Kind() == reflect.Map && isStringKeyed(v.Type()) && field.Value.IsStruct && !isAtomic(field.Value.Type) {
+		return MapGetter(value)
+	} else if v.Kind() != reflect.Slice || !field.Value.IsSlice || !field.Value.ElemTypeInfo.IsStruct || isAtomic(field.Value.ElemType) {
+		return value
+	}
+	getters := make([]Getter, v.Len())
+	for k := range getters {
+		elem := v.Index(k)
+		if elem.Kind() == reflect.Interface {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Map || !isStringKeyed(elem.Type()) {
+			return value
+		}
+		getters[k] = MapGetter(elem.Interface())
+	}
+	return getters
+}
+
+// fillMapFromGetter sets the map wrapped by target to a new map with one element per key of getter, which must
+// implement KeysGetter.  Each key is coerced into the map's key type; each element is coerced from the value
+// returned by getter or, if that value is itself a Getter, the element is filled as a struct or nested map.
+func (me *Value) fillMapFromGetter(target *Value, getter Getter, fillFunc func(*Value, Getter, *fillConfig) error, cfg *fillConfig) error {
+	keys, ok := getter.(KeysGetter)
+	if !ok {
+		return errors.Errorf("Getter for map type %v does not implement KeysGetter", target.Type)
+	}
+	names := keys.Keys()
+	m := reflect.MakeMapWithSize(target.Type, len(names))
+	for _, name := range names {
+		key := reflect.New(target.Type.Key())
+		if err := me.v(key).To(name); err != nil {
+			return errors.Errorf("While coercing map key [%v]: %v", name, err.Error())
+		}
+		elem := me.v(reflect.New(target.ElemType))
+		var err error
+		switch got := getter.Get(name).(type) {
+		case Getter:
+			if elem.IsStruct && !isAtomic(elem.Type) {
+				err = fillFunc(elem, got, cfg)
+			} else if elem.IsMap {
+				err = me.fillMapFromGetter(elem, got, fillFunc, cfg)
+			} else {
+				err = errors.Errorf("Getter.Get( %v ) returned a Getter and element type %v is not fillable", name, target.ElemType)
+			}
+		default:
+			err = elem.To(got)
+		}
+		if err != nil {
+			return errors.Errorf("While coercing map element [%v]: %v", name, err.Error())
+		}
+		m.SetMapIndex(key.Elem(), reflect.Indirect(elem.TopValue))
+	}
+	target.WriteValue.Set(m)
+	return nil
+}
+
+// isUnfillableKind returns true for the kinds of fields that are never filled: channels, functions, and
+// unsafe pointers.
+func isUnfillableKind(K reflect.Kind) bool {
+	return K == reflect.Chan || K == reflect.Func || K == reflect.UnsafePointer
+}
+
+// fillMap sets the map-of-struct field to a new map with one element per getter.  Each element is filled with
+// its getter and inserted under the value of its field named by the field's mapkey struct-tag.
+func (me *Value) fillMap(field Field, getters []Getter, fillFunc func(*Value, Getter, *fillConfig) error, cfg *fillConfig) error {
+	keyName := field.Field.Tag.Get("mapkey")
+	if keyName == "" {
+		return errors.Errorf("Field %v is a map of struct and requires a mapkey struct-tag to be filled from a []Getter.", field.Field.Name)
+	} else if _, ok := field.Value.ElemTypeInfo.Type.FieldByName(keyName); !ok {
+		return errors.Errorf("Field %v has mapkey %v which is not a field of %v.", field.Field.Name, keyName, field.Value.ElemTypeInfo.Type)
+	}
+	m := reflect.MakeMapWithSize(field.Value.Type, len(getters))
+	for k, getter := range getters {
+		elem := me.v(reflect.New(field.Value.ElemType))
+		if err := fillFunc(elem, getter, cfg); err != nil {
+			return errors.Go(err)
+		}
+		key := reflect.New(field.Value.Type.Key())
+		if err := me.v(key).To(elem.WriteValue.FieldByName(keyName).Interface()); err != nil {
+			return errors.Errorf("While setting map key for field %v element %v: %v", field.Field.Name, k, err.Error())
+		}
+		m.SetMapIndex(key.Elem(), reflect.Indirect(elem.TopValue))
+	}
+	field.Value.WriteValue.Set(m)
 	return nil
 }
 
+// fillInterface sub-fills the struct held by the interface field with getter.  A pointer to a struct is filled in
+// place; a struct value is copied, filled, and assigned back into the field.  An error naming the field and its
+// interface type is returned if the interface does not hold a struct.
+func (me *Value) fillInterface(field Field, getName string, getter Getter, fillFunc func(*Value, Getter, *fillConfig) error, cfg *fillConfig) error {
+	held := field.Value.WriteValue
+	if !held.IsNil() {
+		held = held.Elem()
+	}
+	if held.Kind() == reflect.Ptr && !held.IsNil() && held.Elem().Kind() == reflect.Struct {
+		return fillFunc(me.v(held.Interface()), getter, cfg)
+	} else if held.Kind() == reflect.Struct && field.Value.CanWrite {
+		copied := reflect.New(held.Type())
+		copied.Elem().Set(held)
+		if err := fillFunc(me.v(copied), getter, cfg); err != nil {
+			return err
+		}
+		field.Value.WriteValue.Set(copied.Elem())
+		return nil
+	}
+	return errors.Errorf("Getter.Get( %v ) returned a Getter for field %v of interface type %v and the interface does not hold a struct.", getName, field.Field.Name, field.Value.Type)
+}
+
 // Fill iterates a struct's fields and calls Set() on each one by passing the field name to the Getter.
 // Fill stops and returns on the first error encountered.
-func (me *Value) Fill(getter Getter) error {
-	fields := me.Fields()
-	keyFunc := func(field Field) string {
-		return field.Field.Name
+//
+// If the Getter returns a []Getter for a field that is a map of struct then the map is set to a new map with one
+// element per Getter; the field must have a mapkey struct-tag naming the element's field to use as the map key:
+//	type T struct {
+//		Users map[int]User `mapkey:"ID"` // Each User is inserted under its ID.
+//	}
+//
+// If the Getter returns a Getter for a field that is a map then the map is set to a new map with one element
+// per key of the returned Getter, which must implement KeysGetter as the Getters from MapGetter() and
+// PrefixGetter() do; keys and elements are coerced into the map's types and elements that are structs or maps are
+// filled from nested Getters:
+//	type T struct {
+//		Limits map[string]int // getter.Get("Limits") returns MapGetter(map[string]string{"cpu": "2"})
+//	}
+//
+// A map with string or interface{} keys returned for a struct field, or a slice of such maps returned for a
+// field that is a slice of structs, is treated as if the Getter had returned MapGetter() of each map; the nested
+// struct is filled the same way as its parent and with the same opts.
+//
+// If the Getter returns a Getter for a field of interface type then the struct held by the interface, or
+// pointed at by a pointer held by the interface, is filled; otherwise an error naming the field is returned.
+//
+// Fields promoted from embedded structs are also filled by their own names following the Go language's
+// promotion rules; i.e. an outer field shadows a promoted field of the same name.  The embedded struct itself
+// is filled first if the Getter returns a value for its name:
+//	type Meta struct {
+//		CreatedAt time.Time
+//	}
+//	type T struct {
+//		Meta
+//		Name string
+//	}
+//	set.V(&t).Fill(getter) // getter.Get("CreatedAt") fills t.Meta.CreatedAt
+//
+// If the struct, or a nested struct, implements Validator then Validate() is called once it is populated.
+//
+// Fields that are channels, functions, or unsafe pointers are skipped and left untouched.
+//
+// Strings are parsed into time.Time fields with the layout in the field's time or timeformat struct-tag, if
+// present; otherwise the layouts in TimeFormats are tried:
+//	type T struct {
+//		Born    time.Time `time:"2006-01-02"`
+//		Updated time.Time `timeformat:"02/01/2006 15:04"`
+//	}
+//
+// opts are optional and alter the behavior of Fill; see MaxDepth().
+func (me *Value) Fill(getter Getter, opts ...FillOption) error {
+	return me.contextError(me.fillByName(getter, newFillConfig(opts)))
+}
+
+// fillByName is the implementation of Fill().
+func (me *Value) fillByName(getter Getter, cfg *fillConfig) error {
+	fields := promotedFields(me, me.Fields(), getter, fieldName)
+	keyFunc := func(field Field) (string, TagOptions) {
+		return field.Field.Name, TagOptions{}
 	}
-	fillFunc := func(value *Value, getter Getter) error {
-		return value.Fill(getter)
+	fillFunc := func(value *Value, getter Getter, cfg *fillConfig) error {
+		return value.fillByName(getter, cfg)
 	}
-	return me.fill(getter, fields, keyFunc, fillFunc)
+	return me.fill(getter, fields, keyFunc, fillFunc, cfg)
 }
 
-// FillByTag is the same as Fill() except the argument passed to Getter is the value of the struct-tag.
-func (me *Value) FillByTag(key string, getter Getter) error {
+// FillAll sets the slice-of-struct wrapped by Value to a new slice with one element per getter; each element
+// is populated by calling Fill() with its getter and opts.  This is useful for mapping rows of a result set into
+// a slice of structs.
+//
+// If an element can not be filled an error describing the zero based row index is returned and the slice is
+// set to its zero value.
+func (me *Value) FillAll(getters []Getter, opts ...FillOption) error {
+	return me.contextError(me.fillAll(getters, newFillConfig(opts)))
+}
+
+// fillAll is the implementation of FillAll().
+func (me *Value) fillAll(getters []Getter, cfg *fillConfig) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsSlice || !me.ElemTypeInfo.IsStruct {
+		return errors.Errorf(me.errorUnsupported("FillAll"))
+	}
+	slice := reflect.MakeSlice(me.Type, 0, len(getters))
+	for row, getter := range getters {
+		elem := me.v(reflect.New(me.ElemType))
+		if err := elem.fillByName(getter, cfg); err != nil {
+			me.Zero()
+			return errors.Errorf("FillAll row %v: %v", row, err.Error())
+		}
+		slice = reflect.Append(slice, reflect.Indirect(elem.TopValue))
+	}
+	me.WriteValue.Set(slice)
+	return nil
+}
+
+// FillByTag is the same as Fill() except the argument passed to Getter is the name in the struct-tag as
+// parsed by ParseTag(); if the tag has no name then the field name is used.  Fields without the struct-tag
+// or whose tag value is exactly "-" are not filled.
+//
+// The following flag and option in the struct-tag are honored when the Getter returns nil for a field:
+//	required	FillByTag returns an error.
+//	default=V	The field is set to V with To().
+//
+//	type T struct {
+//		ID   int    `set:"id,required"`
+//		Role string `set:"name=role,default=user"`
+//	}
+//	err := set.V(&t).FillByTag("set", getter)
+func (me *Value) FillByTag(key string, getter Getter, opts ...FillOption) error {
+	return me.contextError(me.fillByTag(key, getter, newFillConfig(opts)))
+}
+
+// fillByTag is the implementation of FillByTag().
+func (me *Value) fillByTag(key string, getter Getter, cfg *fillConfig) error {
 	fields := me.FieldsByTag(key)
-	keyFunc := func(field Field) string {
-		return field.TagValue
+	keyFunc := func(field Field) (string, TagOptions) {
+		options := ParseTag(field.TagValue)
+		if options.Name == "" {
+			return field.Field.Name, options
+		}
+		return options.Name, options
+	}
+	fillFunc := func(value *Value, getter Getter, cfg *fillConfig) error {
+		return value.fillByTag(key, getter, cfg)
+	}
+	return me.fill(getter, fields, keyFunc, fillFunc, cfg)
+}
+
+// FillByTags is the same as FillByTag() except each field is looked up by the first struct-tag present
+// among keys; if none of the tags are present the field name is used.  Options following a comma in the
+// tag value are ignored and a field is skipped if the first present tag is exactly "-":
+//	type T struct {
+//		A string `db:"a" json:"json_a"`	// Getter.Get("a")
+//		B string `json:"b,omitempty"`	// Getter.Get("b")
+//		C string				// Getter.Get("C")
+//		D string `json:"-"`		// Skipped.
+//	}
+//	set.V(&t).FillByTags([]string{"db", "json"}, getter)
+func (me *Value) FillByTags(keys []string, getter Getter, opts ...FillOption) error {
+	return me.contextError(me.fillByTags(keys, getter, newFillConfig(opts)))
+}
+
+// fillByTags is the implementation of FillByTags().
+func (me *Value) fillByTags(keys []string, getter Getter, cfg *fillConfig) error {
+	// The name for each field is resolved once while enumerating the fields and stored in TagValue; an empty
+	// TagValue skips the field.
+	fields := me.Fields()
+	for k := range fields {
+		fields[k].TagValue = fields[k].Field.Name
+		for _, key := range keys {
+			if value, ok := fields[k].Field.Tag.Lookup(key); ok {
+				if value == "-" {
+					fields[k].TagValue = ""
+					break
+				} else if name := strings.SplitN(value, ",", 2)[0]; name != "" {
+					fields[k].TagValue = name
+					break
+				}
+			}
+		}
+	}
+	keyFunc := func(field Field) (string, TagOptions) {
+		return field.TagValue, TagOptions{}
+	}
+	fillFunc := func(value *Value, getter Getter, cfg *fillConfig) error {
+		return value.fillByTags(keys, getter, cfg)
+	}
+	return me.fill(getter, fields, keyFunc, fillFunc, cfg)
+}
+
+// FillJSON is the same as Fill() except each field is looked up by the name in its json struct-tag, as
+// encoding/json would; options following a comma, such as omitempty or string, are ignored, fields without
+// the tag or without a name in the tag are looked up by field name, and fields tagged exactly "-" are
+// skipped.  Fields of embedded structs without a json struct-tag are looked up by their own json names.
+//	type T struct {
+//		ID    int    `json:"id,string"`	// Getter.Get("id")
+//		Email string `json:",omitempty"`	// Getter.Get("Email")
+//		Notes string				// Getter.Get("Notes")
+//		Token string `json:"-"`		// Skipped.
+//	}
+//	err := set.V(&t).FillJSON(getter)
+func (me *Value) FillJSON(getter Getter, opts ...FillOption) error {
+	return me.contextError(me.fillJSON(getter, newFillConfig(opts)))
+}
+
+// fillJSON is the implementation of FillJSON().
+func (me *Value) fillJSON(getter Getter, cfg *fillConfig) error {
+	T := me.Type
+	promotedName := func(f reflect.StructField) string {
+		for k := 1; k < len(f.Index); k++ {
+			if _, tagged := T.FieldByIndex(f.Index[:k]).Tag.Lookup("json"); tagged {
+				return "" // encoding/json treats a tagged embedded struct as a named field.
+			}
+		}
+		return jsonName(f)
+	}
+	fields := promotedFields(me, me.Fields(), getter, promotedName)
+	keyFunc := func(field Field) (string, TagOptions) {
+		return jsonName(field.Field), TagOptions{}
+	}
+	fillFunc := func(value *Value, getter Getter, cfg *fillConfig) error {
+		return value.fillJSON(getter, cfg)
+	}
+	return me.fill(getter, fields, keyFunc, fillFunc, cfg)
+}
+
+// jsonName returns the name encoding/json uses for the field or "" if the field is skipped.
+func jsonName(f reflect.StructField) string {
+	tag, ok := f.Tag.Lookup("json")
+	if tag == "-" {
+		return ""
+	} else if name := strings.SplitN(tag, ",", 2)[0]; ok && name != "" {
+		return name
+	}
+	return f.Name
+}
+
+// FillInsensitive is the same as Fill() except a field whose name is not found by the Getter is looked up
+// again with the Getter's key that matches the field name without regard to case; this is similar to
+// the matching performed by encoding/json.
+//
+// Matching without regard to case requires the Getter to implement KeysGetter, as the Getter returned
+// by MapGetter() does; otherwise only exact field names are found.  Nested Getters are matched the same way.
+func (me *Value) FillInsensitive(getter Getter, opts ...FillOption) error {
+	return me.contextError(me.fillInsensitive(getter, newFillConfig(opts)))
+}
+
+// fillInsensitive is the implementation of FillInsensitive().
+func (me *Value) fillInsensitive(getter Getter, cfg *fillConfig) error {
+	insensitive := &insensitiveGetter{getter: getter}
+	fields := promotedFields(me, me.Fields(), insensitive, fieldName)
+	keyFunc := func(field Field) (string, TagOptions) {
+		return field.Field.Name, TagOptions{}
+	}
+	fillFunc := func(value *Value, getter Getter, cfg *fillConfig) error {
+		return value.fillInsensitive(getter, cfg)
+	}
+	return me.fill(insensitive, fields, keyFunc, fillFunc, cfg)
+}
+
+// FillByOrder fills the struct wrapped by Value from values, which must be a slice or array such as a record
+// read from a CSV file without a header; values[i] is assigned with To() to the field whose struct-tag key has
+// the value i.  The tag values must be the integers 0 through len(values)-1 with each appearing exactly once;
+// fields without the tag, or whose tag is exactly "-", are not altered.
+//	type T struct {
+//		Name string `order:"2"`
+//		ID   int    `order:"0"`
+//		Age  int    `order:"1"`
+//	}
+//	err := set.V(&t).FillByOrder("order", []string{"42", "30", "bob"})
+//
+// An error is returned without altering any field if an order is duplicated, missing, or not an integer;
+// otherwise an error is returned for the first value that can not be coerced.
+func (me *Value) FillByOrder(key string, values interface{}) error {
+	return me.contextError(me.fillByOrder(key, values))
+}
+
+// fillByOrder is the implementation of FillByOrder().
+func (me *Value) fillByOrder(key string, values interface{}) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsStruct {
+		return errors.Errorf(me.errorUnsupported("FillByOrder"))
+	}
+	data := reflect.ValueOf(values)
+	if kind := data.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return errors.Errorf("FillByOrder expects values to be a slice or array; got [%T]", values)
+	}
+	fields := make([]*Field, data.Len())
+	tagged := me.FieldsByTag(key)
+	for k := range tagged {
+		field := &tagged[k]
+		if field.Field.PkgPath != "" {
+			continue
+		}
+		order, err := strconv.Atoi(strings.TrimSpace(strings.SplitN(field.TagValue, ",", 2)[0]))
+		if err != nil {
+			return errors.Errorf("Field %v has %v %q which is not an integer.", field.Field.Name, key, field.TagValue)
+		} else if order < 0 || order >= len(fields) {
+			return errors.Errorf("Field %v has %v %v which is out of range for %v values.", field.Field.Name, key, order, len(fields))
+		} else if fields[order] != nil {
+			return errors.Errorf("Fields %v and %v have the same %v %v.", fields[order].Field.Name, field.Field.Name, key, order)
+		}
+		fields[order] = field
+	}
+	for order, field := range fields {
+		if field == nil {
+			return errors.Errorf("No field has %v %v.", key, order)
+		}
+	}
+	for order, field := range fields {
+		if err := field.Value.To(data.Index(order).Interface()); err != nil {
+			return errors.Errorf("While setting field %v from index %v: %v", field.Field.Name, order, err.Error())
+		}
+	}
+	return nil
+}
+
+// FillStream decodes a stream of JSON objects from dec and appends one element per object to the
+// slice-of-struct wrapped by Value; each element is populated by calling Fill() with a MapGetter around
+// the decoded object.  Decoding stops at io.EOF.
+//
+// Objects are decoded one at a time so the stream is never held in memory in its entirety.  If an object
+// can not be decoded or filled an error describing the zero based record index is returned and the elements
+// for the previous records remain appended.
+func (me *Value) FillStream(dec *json.Decoder) error {
+	return me.contextError(me.fillStream(dec))
+}
+
+// fillStream is the implementation of FillStream().
+func (me *Value) fillStream(dec *json.Decoder) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsSlice || !me.ElemTypeInfo.IsStruct {
+		return errors.Errorf(me.errorUnsupported("FillStream"))
+	}
+	for record := 0; ; record++ {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Errorf("FillStream record %v: %v", record, err.Error())
+		}
+		elem := me.v(reflect.New(me.ElemType))
+		if err := elem.Fill(MapGetter(m)); err != nil {
+			return errors.Errorf("FillStream record %v: %v", record, err.Error())
+		}
+		me.WriteValue.Set(reflect.Append(me.WriteValue, reflect.Indirect(elem.TopValue)))
+	}
+}
+
+// Grow increases the capacity of the slice, if necessary, so another n elements can be appended without
+// another allocation; the length and elements of the slice are unchanged.
+func (me *Value) Grow(n int) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsSlice {
+		return errors.Errorf(me.errorUnsupported("Grow"))
+	} else if n < 0 {
+		return errors.Errorf("Grow( %v ) with negative size", n)
+	}
+	length := me.WriteValue.Len()
+	if me.WriteValue.Cap()-length < n {
+		grown := reflect.MakeSlice(me.Type, length, length+n)
+		reflect.Copy(grown, me.WriteValue)
+		me.WriteValue.Set(grown)
+	}
+	return nil
+}
+
+// SetLen sets the length of the slice to n; if n is greater than the capacity of the slice then the capacity
+// is increased first.  New elements are zero values and elements beyond n are no longer part of the slice.
+func (me *Value) SetLen(n int) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsSlice {
+		return errors.Errorf(me.errorUnsupported("SetLen"))
+	} else if n < 0 {
+		return errors.Errorf("SetLen( %v ) with negative size", n)
+	}
+	length := me.WriteValue.Len()
+	if n > me.WriteValue.Cap() {
+		if err := me.Grow(n - length); err != nil {
+			return errors.Go(err)
+		}
+	} else if n > length {
+		// Elements between the current length and capacity may hold stale values.
+		tail := me.WriteValue.Slice(length, n)
+		for k := 0; k < tail.Len(); k++ {
+			tail.Index(k).Set(reflect.Zero(me.ElemType))
+		}
+	}
+	me.WriteValue.SetLen(n)
+	return nil
+}
+
+// Splice removes deleteCount elements of the slice starting at index start and inserts items in their place,
+// similar to splice in JavaScript; items are coerced into the slice's element type as if by Append():
+//	s := []int{1, 2, 3, 4}
+//	err := set.V(&s).Splice(1, 2, "20", 30.0, 40)	// s is []int{1, 20, 30, 40, 4}
+//	err = set.V(&s).Splice(5, 0, 50)		// s is []int{1, 20, 30, 40, 4, 50}
+//
+// An error is returned if the bounds are not 0 <= start <= start+deleteCount <= len(s) or if an item can not be
+// coerced; in either case the slice is unaltered.  The spliced elements are copied into a new slice so the
+// original backing storage is never written.
+func (me *Value) Splice(start, deleteCount int, items ...interface{}) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || !me.IsSlice {
+		return errors.Errorf(me.errorUnsupported("Splice"))
+	} else if size := me.WriteValue.Len(); start < 0 || deleteCount < 0 || start > size || deleteCount > size-start {
+		return errors.Errorf("Splice( %v, %v ) out of bounds for length %v", start, deleteCount, size)
+	} else if deleteCount == 0 && len(items) == 0 {
+		return nil
+	}
+	inserted := reflect.New(me.Type)
+	if err := me.v(inserted).Append(items...); err != nil {
+		return errors.Go(err)
+	}
+	size, end := me.WriteValue.Len(), start+deleteCount
+	spliced := reflect.MakeSlice(me.Type, 0, size-deleteCount+len(items))
+	spliced = reflect.AppendSlice(spliced, me.WriteValue.Slice(0, start))
+	spliced = reflect.AppendSlice(spliced, inserted.Elem())
+	spliced = reflect.AppendSlice(spliced, me.WriteValue.Slice(end, size))
+	me.WriteValue.Set(spliced)
+	return nil
+}
+
+// IsZero returns true if the Value is the zero value for its type as reported by reflect.Value.IsZero();
+// a Value wrapped around an invalid or nil value is also considered zero.
+//
+// An error is returned for a nil receiver.
+func (me *Value) IsZero() (bool, error) {
+	if me == nil {
+		return false, errors.NilReceiver()
+	} else if !me.WriteValue.IsValid() {
+		return !me.TopValue.IsValid() || me.TopValue.IsZero(), nil
+	}
+	return me.WriteValue.IsZero(), nil
+}
+
+// ForEach calls fn once for each element of the slice, array, or map wrapped by Value or for each field of the
+// wrapped struct; iteration stops and the error is returned if fn returns an error.
+//
+// The key passed to fn depends on the wrapped type:
+//	slice, array	the int index of the element
+//	map		the map key; keys are visited in the order returned by MapKeysSorted()
+//	struct		the string name of the field; fields are visited in declaration order
+//
+// Map elements are not addressable; the *Value passed to fn for a map element wraps a copy of the element and
+// changes to it do not alter the map.
+func (me *Value) ForEach(fn func(key interface{}, v *Value) error) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.WriteValue.IsValid() {
+		return errors.Errorf(me.errorUnsupported("ForEach"))
+	}
+	switch me.Kind {
+	case reflect.Slice, reflect.Array:
+		for k, size := 0, me.WriteValue.Len(); k < size; k++ {
+			if err := fn(k, me.v(me.WriteValue.Index(k))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// Keys and elements are collected with MapRange() because keys such as NaN or a nil interface can
+		// not be looked up again with MapIndex().
+		var keys, elems []reflect.Value
+		for iter := me.WriteValue.MapRange(); iter.Next(); {
+			keys, elems = append(keys, iter.Key()), append(elems, iter.Value())
+		}
+		order := make([]int, len(keys))
+		for k := range order {
+			order[k] = k
+		}
+		if less := mapKeyLess(me.Type.Key().Kind()); less != nil {
+			sort.SliceStable(order, func(i, j int) bool { return less(keys[order[i]], keys[order[j]]) })
+		}
+		for _, k := range order {
+			if err := fn(keys[k].Interface(), me.v(elems[k].Interface())); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for _, field := range me.Fields() {
+			if err := fn(field.Field.Name, field.Value); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf(me.errorUnsupported("ForEach"))
 	}
-	fillFunc := func(value *Value, getter Getter) error {
-		return value.FillByTag(key, getter)
+	return nil
+}
+
+// WriteFields calls fn with the name and current value of each exported field of the struct wrapped by Value
+// without building an intermediate map; e.g. to emit the fields to a structured logger.  Fields are visited in
+// declaration order.
+//
+// Fields that are structs, or pointers to structs, are descended into and their fields are named by joining
+// the names with a period; atomic types such as time.Time are passed to fn as values.  Nil pointers are passed
+// to fn as nil and are not instantiated.
+//	type Address struct {
+//		City string
+//	}
+//	type Person struct {
+//		Name    string
+//		Address Address
+//	}
+//	set.V(&p).WriteFields(func(name string, value interface{}) { ... }) // "Name", "Address.City"
+//
+// An error is returned if Value is not a struct.
+func (me *Value) WriteFields(fn func(name string, value interface{})) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if me.Kind != reflect.Struct || !me.WriteValue.IsValid() {
+		return errors.Errorf(me.errorUnsupported("WriteFields"))
 	}
-	return me.fill(getter, fields, keyFunc, fillFunc)
+	writeFields(me.WriteValue, "", fn)
+	return nil
+}
+
+// writeFields implements WriteFields for the struct v; prefix is prepended to every name.
+func writeFields(v reflect.Value, prefix string, fn func(name string, value interface{})) {
+	T := v.Type()
+	for k, size := 0, T.NumField(); k < size; k++ {
+		field := T.Field(k)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, fv := prefix+field.Name, v.Field(k)
+		if elem := indirect(fv); !elem.IsValid() {
+			fn(name, nil)
+		} else if elem.Kind() == reflect.Struct && !isAtomic(elem.Type()) {
+			writeFields(elem, name+".", fn)
+		} else {
+			fn(name, elem.Interface())
+		}
+	}
+}
+
+// MapKeys returns the keys of the map wrapped by Value.  Keys are returned in Go's map iteration order,
+// which is random; see MapKeysSorted() if you need a deterministic order.
+func (me *Value) MapKeys() ([]interface{}, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if !me.IsMap || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("MapKeys"))
+	}
+	keys := me.WriteValue.MapKeys()
+	rv := make([]interface{}, len(keys))
+	for k, key := range keys {
+		rv[k] = key.Interface()
+	}
+	return rv, nil
+}
+
+// MapKeysSorted is the same as MapKeys() except keys are sorted when the map's key type is an ordered
+// scalar:
+//	string			sorted lexically
+//	int, uint, float	sorted numerically
+//	bool			false before true
+//
+// Keys of any other type, such as structs or interfaces, are returned in Go's map iteration order; in
+// other words the order is not deterministic for those types.
+func (me *Value) MapKeysSorted() ([]interface{}, error) {
+	rv, err := me.MapKeys()
+	if err != nil {
+		return nil, errors.Go(err)
+	}
+	keys := make([]reflect.Value, len(rv))
+	for k, key := range rv {
+		keys[k] = reflect.ValueOf(key)
+	}
+	less := mapKeyLess(me.Type.Key().Kind())
+	if less == nil {
+		return rv, nil
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	for k, key := range keys {
+		rv[k] = key.Interface()
+	}
+	return rv, nil
+}
+
+// mapKeyLess returns the function MapKeysSorted() uses to order map keys of the given kind; nil is returned
+// if keys of that kind are not ordered.
+func mapKeyLess(kind reflect.Kind) func(a, b reflect.Value) bool {
+	switch kind {
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() < b.String() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.Bool:
+		return func(a, b reflect.Value) bool { return !a.Bool() && b.Bool() }
+	}
+	return nil
+}
+
+// PkgPath returns the package path of the type described by TypeInfo; an empty string is returned
+// for a nil receiver, an invalid type, or a predeclared or unnamed type.
+func (me *Value) PkgPath() string {
+	if me == nil || me.Type == nil {
+		return ""
+	}
+	return me.Type.PkgPath()
+}
+
+// TypeName returns the name of the type described by TypeInfo; an empty string is returned for a nil
+// receiver, an invalid type, or an unnamed type.
+func (me *Value) TypeName() string {
+	if me == nil || me.Type == nil {
+		return ""
+	}
+	return me.Type.Name()
+}
+
+// Pointer returns the result of reflect.Value.Pointer() for the value originally passed to V(); i.e. the
+// address held by a pointer or the address of the first element of a slice.  The second return value is false
+// if the value is nil or its kind is not a pointer, slice, map, chan, func, or unsafe.Pointer.
+//
+// Pointer is useful as a key for caches keyed by identity or for detecting cycles.
+func (me *Value) Pointer() (uintptr, bool) {
+	if me == nil || !me.TopValue.IsValid() {
+		return 0, false
+	}
+	switch me.TopValue.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if me.TopValue.IsNil() {
+			return 0, false
+		}
+		return me.TopValue.Pointer(), true
+	}
+	return 0, false
 }
 
 // Rebind will swap the underlying original value used to create *Value with the incoming
@@ -370,15 +1443,228 @@ func (me *Value) Zero() error {
 	return nil
 }
 
+// SubSlice returns a *Value wrapping s[low:high] where s is the slice wrapped by Value; an error is returned
+// if Value is not a slice or if the bounds are not 0 <= low <= high <= len(s).
+//
+// The sub-slice shares its backing storage with s so changes to its elements, such as through
+// WriteValue.Index(), are seen in s.  However operations that replace the sub-slice itself, such as
+// To() or Append(), replace only the sub-slice and do not alter s.
+func (me *Value) SubSlice(low, high int) (*Value, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if !me.IsSlice || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("SubSlice"))
+	} else if size := me.WriteValue.Len(); low < 0 || high < low || high > size {
+		return nil, errors.Errorf("SubSlice( %v, %v ) out of bounds for length %v", low, high, size)
+	}
+	ptr := reflect.New(me.Type)
+	ptr.Elem().Set(me.WriteValue.Slice(low, high))
+	return me.v(ptr), nil
+}
+
+// Filter returns a *Value wrapping a new slice with the elements of the slice wrapped by Value for which keep
+// returns true; the elements are copied in order and the new slice is nil if no elements are kept.  The wrapped
+// slice is not altered.
+//
+// Each element is passed to keep as a *Value wrapping a copy of the element so keep can not alter the wrapped
+// slice; however elements that are pointers still point at the same memory.
+//	var ints []int = []int{1, 2, 3, 4}
+//	even, err := set.V(ints).Filter(func(v *set.Value) bool {
+//		return v.WriteValue.Int()%2 == 0
+//	}) // even.WriteValue.Interface() is []int{2, 4}
+func (me *Value) Filter(keep func(*Value) bool) (*Value, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if keep == nil {
+		return nil, errors.NilArgument("keep")
+	} else if !me.IsSlice || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("Filter"))
+	}
+	kept := reflect.Zero(me.Type)
+	for k, size := 0, me.WriteValue.Len(); k < size; k++ {
+		elem := reflect.New(me.ElemType).Elem()
+		elem.Set(me.WriteValue.Index(k))
+		if keep(me.v(elem)) {
+			kept = reflect.Append(kept, me.WriteValue.Index(k))
+		}
+	}
+	ptr := reflect.New(me.Type)
+	ptr.Elem().Set(kept)
+	return me.v(ptr), nil
+}
+
+// MapEach calls fn once for each element of the slice wrapped by Value and returns a *Value wrapping a new slice
+// of the results in order.  The element type of the new slice is the type of the first non-nil result, or
+// interface{} if every result is nil; the other results are coerced into that type with To().  If fn returns an
+// error, or a result can not be coerced, an ElemError with the element's index is returned.
+//
+// As with Filter() each element is passed to fn as a *Value wrapping a copy of the element.
+//	ints := []int{1, 2, 3}
+//	strs, err := set.V(ints).MapEach(func(v *set.Value) (interface{}, error) {
+//		return fmt.Sprintf("#%v", v.WriteValue.Int()), nil
+//	}) // strs.WriteValue.Interface() is []string{"#1", "#2", "#3"}
+func (me *Value) MapEach(fn func(*Value) (interface{}, error)) (*Value, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if fn == nil {
+		return nil, errors.NilArgument("fn")
+	} else if !me.IsSlice || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("MapEach"))
+	}
+	size := me.WriteValue.Len()
+	results := make([]interface{}, size)
+	var T reflect.Type
+	for k := 0; k < size; k++ {
+		elem := reflect.New(me.ElemType).Elem()
+		elem.Set(me.WriteValue.Index(k))
+		result, err := fn(me.v(elem))
+		if err != nil {
+			return nil, ElemError{Index: k, Err: err}
+		} else if T == nil && result != nil {
+			T = reflect.TypeOf(result)
+		}
+		results[k] = result
+	}
+	if T == nil {
+		T = reflect.TypeOf((*interface{})(nil)).Elem()
+	}
+	mapped := reflect.MakeSlice(reflect.SliceOf(T), 0, size)
+	for k, result := range results {
+		if result != nil && reflect.TypeOf(result) == T {
+			mapped = reflect.Append(mapped, reflect.ValueOf(result))
+			continue
+		}
+		elem := reflect.New(T)
+		if err := me.v(elem).To(result); err != nil {
+			return nil, ElemError{Index: k, Err: err}
+		}
+		mapped = reflect.Append(mapped, elem.Elem())
+	}
+	ptr := reflect.New(mapped.Type())
+	ptr.Elem().Set(mapped)
+	return me.v(ptr), nil
+}
+
+// SetZeroIf sets Value to its zero value if pred returns true.
+//
+// If pred returns false and Value is a struct then SetZeroIf is applied to each of its exported fields,
+// visiting nested structs recursively; fields that are nil pointers are skipped and not instantiated.
+// Registered atomic types such as time.Time are not visited field-wise.
+//	// Zero all strings that are only whitespace.
+//	err := set.V(&t).SetZeroIf(func(v *set.Value) bool {
+//		return v.Kind == reflect.String && strings.TrimSpace(v.WriteValue.String()) == ""
+//	})
+func (me *Value) SetZeroIf(pred func(*Value) bool) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if pred == nil {
+		return errors.NilArgument("pred")
+	} else if !me.CanWrite || me.Kind == reflect.Invalid {
+		return errors.Errorf(me.errorUnsupported("SetZeroIf"))
+	} else if pred(me) {
+		return me.Zero()
+	} else if !me.IsStruct || isAtomic(me.Type) {
+		return nil
+	}
+	for k, max := 0, me.Type.NumField(); k < max; k++ {
+		field := me.Type.Field(k)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldValue := me.WriteValue.Field(k)
+		if !indirect(fieldValue).IsValid() {
+			continue
+		}
+		if err := me.v(fieldValue).SetZeroIf(pred); err != nil {
+			return errors.Errorf("While zeroing field %v: %v", field.Name, err.Error())
+		}
+	}
+	return nil
+}
+
 // NewElem instantiates and returns a *Value that can be Panics.Append()'ed to this type; only valid
-// if Value.ElemType describes a valid type.
+// if Value.ElemType describes a valid type, i.e. Value is a map, slice, or array.  For arrays the returned
+// *Value is a template of the element type that is not part of the array.
 func (me *Value) NewElem() (*Value, error) {
 	if me == nil {
 		return nil, errors.NilReceiver()
 	} else if me.ElemTypeInfo.Kind == reflect.Invalid {
 		return nil, errors.Errorf(me.errorUnsupported("NewElem"))
 	}
-	return V(reflect.New(me.ElemType)), nil
+	return me.v(reflect.New(me.ElemType)), nil
+}
+
+// NewElemInto is the same as NewElem() except the element is created in dst, which is reused between calls
+// to avoid allocating a new *Value and element each time.  If dst was populated by a previous call to
+// NewElemInto() for the same element type then its element is reset to the zero value; otherwise dst is
+// overwritten with the result of NewElem().
+//
+// Reuse is safe for append loops because Panics.Append() copies the element; when the element type is a pointer
+// a new pointer is instantiated on each call so previously appended elements are never aliased.
+//	elem := &set.Value{}
+//	for _, row := range rows {
+//		if err := slice.NewElemInto(elem); err != nil {
+//			return err
+//		}
+//		// Populate elem, e.g. elem.Fill(row)
+//		set.Panics.Append(slice, elem)
+//	}
+//
+// Do not retain dst, or its WriteValue, after the next call; its element is overwritten.
+func (me *Value) NewElemInto(dst *Value) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if dst == nil {
+		return errors.NilArgument("dst")
+	} else if me.ElemTypeInfo.Kind == reflect.Invalid {
+		return errors.Errorf(me.errorUnsupported("NewElemInto"))
+	}
+	if top := dst.TopValue; top.IsValid() && top.Kind() == reflect.Ptr && top.Type().Elem() == me.ElemType && !top.IsNil() {
+		top.Elem().Set(reflect.Zero(me.ElemType))
+		dst.WriteValue, dst.CanWrite = Writable(top)
+		dst.options, dst.nilled = me.options, false
+		return nil
+	}
+	*dst = *me.v(reflect.New(me.ElemType))
+	return nil
+}
+
+// toSameType assigns arg without reflection when V() was called with a pointer to one of the most common scalar
+// types and arg is the same type; the first return value is false if arg was not assigned.
+//
+// Performance note(s):
+//	The switch on me.original avoids reflect.Value.Set and the checks in To() for the hottest case, e.g.
+//	set.V(&n).To(42).  Options are never set when this is called so options such as TrimSpace need not be
+//	considered.
+func (me *Value) toSameType(arg interface{}) (bool, error) {
+	switch ptr := me.original.(type) {
+	case *int:
+		if v, ok := arg.(int); ok && ptr != nil {
+			*ptr = v
+			return true, checkEnum(me.WriteValue)
+		}
+	case *int64:
+		if v, ok := arg.(int64); ok && ptr != nil {
+			*ptr = v
+			return true, checkEnum(me.WriteValue)
+		}
+	case *float64:
+		if v, ok := arg.(float64); ok && ptr != nil {
+			*ptr = v
+			return true, checkEnum(me.WriteValue)
+		}
+	case *string:
+		if v, ok := arg.(string); ok && ptr != nil {
+			*ptr = v
+			return true, checkEnum(me.WriteValue)
+		}
+	case *bool:
+		if v, ok := arg.(bool); ok && ptr != nil {
+			*ptr = v
+			return true, checkEnum(me.WriteValue)
+		}
+	}
+	return false, nil
 }
 
 // To attempts to assign the argument into Value.
@@ -404,12 +1690,38 @@ func (me *Value) NewElem() (*Value, error) {
 //		-> T is assigned S[ len( S ) - 1 ]; i.e. last element in S if length greater than 0.
 //	T is slice []T, S is scalar
 //		-> T is set to []T{ S }; i.e. a slice of T with S as the only element.
+//		-> Note: This also applies when S is a struct and T is a slice of that struct type, or of pointers to it.
 //	T is slice []T, S is slice []S
 //		-> T is set to []T{ S... }; i.e. a new slice with elements from S copied.
 //		-> Note: T != S; they are now different slices; changes to T do not affect S and vice versa.
 //		-> Note: If the elements themselves are pointers then, for example, T[0] and S[0] point
 //			at the same memory and will see changes to whatever is pointed at.
+//		-> Note: If an element of S can not be coerced T is set to its zero value and an ElemError with the
+//			element's index is returned; see Options.SkipInvalidElems to skip such elements instead.
+//	T is map map[K]T, S is map map[L]S, different types
+//		-> T is set to a new map with every key L coerced into K and every element S coerced into T.
+//	T is unsigned, S is a negative number or string holding one
+//		-> an error is returned and T is set to 0; see Options.ClampUnsigned to set 0 without an error.
+//	T is struct, S is map with string or interface{} keys
+//		-> T is filled from S as if by FillInsensitive(MapGetter(S)); nested maps fill nested structs.
+//	T is string, S is struct implementing encoding.TextMarshaler
+//		-> T is assigned the marshaled text of S; e.g. time.Time, big.Int, or big.Float.
+//	T is string, S is not a scalar and implements fmt.Stringer
+//		-> T is assigned S.String() when no other rule applies.
+//	T is slice, array, or struct implementing encoding.TextUnmarshaler, S is string
+//		-> T is unmarshaled from S; e.g. net.IP.
+//	T is numeric, S is time.Time; or T is time.Time, S is numeric
+//		-> the number is a Unix epoch in seconds or Options.EpochUnit; times are created in UTC.
+//	T is time.Duration or an integer, S is a string such as "1h30m" or "10MB"
+//		-> see Options.ParseDurations and Options.ParseByteSizes.
+//	T is a registered atomic type
+//		-> see RegisterAtomic().
 func (me *Value) To(arg interface{}) error {
+	return me.contextError(me.to(arg))
+}
+
+// to is the implementation of To().
+func (me *Value) to(arg interface{}) error {
 	// Performance note(s):
 	//	Early versions of this called me.Zero() and then simply returned on error or for incompatible types.
 	//	It turns out the call to Zero() can be relatively expensive in terms of ns/op and memory allocations.
@@ -418,12 +1730,27 @@ func (me *Value) To(arg interface{}) error {
 	//
 	if me == nil {
 		return errors.NilReceiver()
-	} else if me.original == nil || !me.CanWrite || me.Kind == reflect.Invalid {
+	} else if me.options == nil {
+		if handled, err := me.toSameType(arg); handled {
+			return err
+		}
+	}
+	if me.original == nil || !me.CanWrite || me.Kind == reflect.Invalid {
 		return errors.Errorf(me.errorUnsupported("To"))
 	}
+	if me.nilled {
+		me.WriteValue, _ = Writable(me.TopValue)
+		me.nilled = false
+	}
+	if me.options != nil && me.options.TrimSpace {
+		arg = trimSpace(arg)
+	}
 	T := reflect.TypeOf(arg)
 	if arg == nil || T == nil {
 		return me.Zero()
+	} else if str, ok := arg.(string); ok && me.isNullToken(str) && me.setNilPointer() {
+		me.nilled = true
+		return nil
 	} else if (T == me.Type || T.AssignableTo(me.Type)) && me.Kind != reflect.Slice {
 		// N.B: We checked that me.Kind is not a slice because this package always makes a copy of a slice!
 		//
@@ -436,6 +1763,11 @@ func (me *Value) To(arg interface{}) error {
 		//		For basic built-in types this is relatively expensive, hence the type switch.
 		//		Pre-bench: 		210ms within To() (9.50% of Total), 140ms in original statement.
 		//		Post-bench:		50ms within To() (4.20% of Total), 10ms spread across calls to me.WriteValue.SetT()
+		if me.Kind == reflect.Interface {
+			// The typed setters in the switch below panic when the destination is an interface.
+			me.WriteValue.Set(reflect.ValueOf(arg))
+			return nil
+		}
 		switch tt := arg.(type) {
 		case bool:
 			me.WriteValue.SetBool(tt)
@@ -468,7 +1800,7 @@ func (me *Value) To(arg interface{}) error {
 		default:
 			me.WriteValue.Set(reflect.ValueOf(arg))
 		}
-		return nil
+		return checkEnum(me.WriteValue)
 	}
 	//
 	// If arg/data represents any type of pointer we want to get to the final value:
@@ -478,33 +1810,296 @@ func (me *Value) To(arg interface{}) error {
 			return me.Zero()
 		}
 	}
+	if me.IsScalar && isScalarKind(dataValue.Kind()) {
+		if dataValue.Kind() == reflect.String && me.options != nil && me.options.EmptyAsZero &&
+			(me.Kind == reflect.Bool || numericKind(me.Kind) != "") && strings.TrimSpace(dataValue.String()) == "" {
+			return me.Zero()
+		}
+		if dataValue.Kind() == reflect.String && me.options != nil && me.options.Humanize && numericKind(me.Kind) != "" {
+			humanized, err := me.humanize(dataValue.String())
+			if err != nil {
+				me.Zero()
+				return newCoerceError(me.WriteValue, dataValue, err)
+			}
+			dataValue = humanized
+		}
+		if dataValue.Kind() == reflect.String && me.options != nil && (me.options.ParseDurations || me.options.ParseByteSizes) {
+			var parse func(string) (reflect.Value, bool, error)
+			if me.Type == typeDuration && me.options.ParseDurations {
+				parse = parseDuration
+			} else if me.Type != typeDuration && me.options.ParseByteSizes && (numericKind(me.Kind) == "int" || numericKind(me.Kind) == "uint") {
+				parse = parseByteSize
+			}
+			if parse != nil {
+				parsed, handled, err := parse(dataValue.String())
+				if err != nil {
+					me.Zero()
+					return newCoerceError(me.WriteValue, dataValue, err)
+				} else if handled && sizeOverflows(me.WriteValue, parsed) {
+					me.Zero()
+					return newCoerceError(me.WriteValue, dataValue, errors.Errorf("size %q overflows %v", dataValue.String(), me.Type))
+				} else if handled {
+					dataValue = parsed
+				}
+			}
+		}
+		if me.options != nil && me.options.ClampUnsigned && numericKind(me.Kind) == "uint" && isNegative(dataValue) {
+			return me.Zero()
+		}
+		// Scalar into scalar is the most common case and does not need the TypeInfo for dataValue; coerce()
+		// overwrites the destination on success and zeroes it on failure so Zero() is not called first.
+		if dataValue.Type() == me.Type {
+			me.WriteValue.Set(dataValue)
+			return checkEnum(me.WriteValue)
+		} else if err := coerce(me.WriteValue, dataValue); err != nil {
+			return err // Not wrapped so a *CoerceError can be found with errors.As().
+		}
+		return nil
+	}
 	dataTypeInfo := TypeCache.StatType(dataValue.Type())
 	//
-	if me.IsSlice {
-		me.Zero() // Zero only returns errors on nil receiver, invalid kind, or !CanWrite -- which are already checked above.
-		if !dataTypeInfo.IsSlice {
-			arg = []interface{}{arg}
+	if !me.IsScalar && !isAtomic(me.Type) && dataValue.Kind() == reflect.String {
+		// Strings are unmarshaled into types such as net.IP that implement encoding.TextUnmarshaler.
+		if handled, err := unmarshalText(me.WriteValue, dataValue); handled {
+			if err != nil {
+				me.Zero()
+				return newCoerceError(me.WriteValue, dataValue, err)
+			}
+			return nil
+		}
+	}
+	if T != dataValue.Type() && dataValue.Type().AssignableTo(me.Type) && me.Kind != reflect.Slice && !isAtomic(me.Type) {
+		// arg was a pointer to a type assignable to ours; assign the value at the end of the pointer chain
+		// so we do not alias arg.
+		me.WriteValue.Set(dataValue)
+		return checkEnum(me.WriteValue)
+	} else if me.IsSlice {
+		if dataTypeInfo.IsSlice && !isEnum(me.ElemType) {
+			// Fast path for numeric slices; converts elements directly without creating a *Value per element.
+			if converted, ok := convertNumericSlice(me.Type, dataValue); ok {
+				me.WriteValue.Set(converted)
+				return nil
+			}
 		}
-		slice := reflect.ValueOf(arg)
+		// The elements are appended to a new slice that is assigned only once every element has been coerced; the
+		// destination is never left holding some of the elements and arg may refer to the destination itself.
+		isSlice := dataTypeInfo.IsSlice
+		slice := dataValue
+		if !isSlice {
+			slice = reflect.ValueOf([]interface{}{arg})
+		}
+		rv := reflect.Zero(me.Type)
+		var skipped ElemErrors
 		for k, size := 0, slice.Len(); k < size; k++ {
-			elem := V(reflect.New(me.ElemType).Interface())
+			elem := me.v(reflect.New(me.ElemType).Interface())
 			if err := elem.To(slice.Index(k).Interface()); err != nil {
-				me.Zero()
+				if me.opts().SkipInvalidElems {
+					skipped = append(skipped, ElemError{Index: k, Err: err})
+					continue
+				}
+				me.Zero() // Zero only returns errors on nil receiver, invalid kind, or !CanWrite -- which are already checked above.
+				if isSlice {
+					return ElemError{Index: k, Err: err}
+				}
 				return err
 			}
-			me.WriteValue.Set(reflect.Append(me.WriteValue, elem.WriteValue))
+			rv = reflect.Append(rv, reflect.Indirect(elem.TopValue))
+		}
+		me.WriteValue.Set(rv)
+		if skipped != nil {
+			return skipped
+		}
+		return nil
+	} else if me.IsMap && dataTypeInfo.IsMap {
+		// Both are maps; a new map is created and every key and element is coerced into it.
+		m := reflect.MakeMapWithSize(me.Type, dataValue.Len())
+		iter := dataValue.MapRange()
+		for iter.Next() {
+			key, elem := reflect.New(me.Type.Key()), reflect.New(me.ElemType)
+			if err := me.v(key).To(iter.Key().Interface()); err != nil {
+				me.Zero()
+				return errors.Errorf("While coercing map key [%v]: %v", iter.Key().Interface(), err.Error())
+			}
+			elemAsValue := me.v(elem)
+			if err := elemAsValue.To(iter.Value().Interface()); err != nil {
+				me.Zero()
+				return errors.Errorf("While coercing map element [%v]: %v", iter.Key().Interface(), err.Error())
+			}
+			m.SetMapIndex(key.Elem(), reflect.Indirect(elemAsValue.TopValue))
+		}
+		me.WriteValue.Set(m)
+		return nil
+	} else if me.IsStruct && !isAtomic(me.Type) && dataTypeInfo.IsMap && isStringKeyed(dataValue.Type()) {
+		// A map, such as one decoded from JSON, is coerced into a struct by filling the struct from the map; nested
+		// maps fill nested structs.
+		if err := me.fillInsensitive(MapGetter(dataValue.Interface()), newFillConfig(nil)); err != nil {
+			me.Zero()
+			return errors.Go(err)
 		}
 		return nil
 	} else if dataTypeInfo.Kind == reflect.Slice {
 		// If the incoming type is slice but ours is not then we call set again using the last element in the slice.
 		if dataValue.Len() > 0 {
-			return me.To(dataValue.Index(dataValue.Len() - 1).Interface())
+			return me.to(dataValue.Index(dataValue.Len() - 1).Interface())
 		}
 	} else if me.IsScalar {
+		if dataValue.Type() == typeTime && numericKind(me.Kind) != "" {
+			unit, err := me.epochUnit()
+			if err != nil {
+				me.Zero()
+				return errors.Go(err)
+			}
+			float := me.Kind == reflect.Float32 || me.Kind == reflect.Float64
+			return coerce(me.WriteValue, unixEpoch(dataValue.Interface().(time.Time), unit, float))
+		}
+		if me.Kind == reflect.String && dataTypeInfo.Kind == reflect.Struct {
+			if text, ok := marshalText(dataValue); ok {
+				me.WriteValue.SetString(text)
+				return checkEnum(me.WriteValue)
+			}
+		}
+		if me.Kind == reflect.String {
+			// Scalars never reach this branch so fmt.Stringer is a last resort before coerce() fails.
+			if text, ok := stringerText(dataValue); ok {
+				me.WriteValue.SetString(text)
+				return checkEnum(me.WriteValue)
+			}
+		}
 		if err := coerce(me.WriteValue, dataValue); err != nil {
-			return errors.Go(err)
+			return err
+		}
+		return nil
+	} else if isAtomic(me.Type) {
+		if me.Type == typeTime && numericKind(dataValue.Kind()) != "" {
+			unit, err := me.epochUnit()
+			if err == nil {
+				_, err = coerceTime(me.WriteValue, dataValue, unit)
+			}
+			if err != nil {
+				me.Zero()
+				return newCoerceError(me.WriteValue, dataValue, err)
+			}
+			return nil
+		}
+		if err := coerceAtomic(me.WriteValue, dataValue); err != nil {
+			me.Zero()
+			return err
 		}
 		return nil
 	}
 	return me.Zero()
 }
+
+// Set assigns rv into Value with the same rules as To(); when rv's type is assignable to Value's type, other than
+// slices which To() always copies, rv is assigned directly without first converting it to an interface{}.  All
+// other values are coerced by To() and Value is zeroed if coercion fails.  An invalid rv sets Value to its zero
+// value.
+//	var n int64
+//	err := set.V(&n).Set(reflect.ValueOf(int64(42)))	// assigned directly
+//	err = set.V(&n).Set(reflect.ValueOf("42"))		// coerced as if by To("42")
+func (me *Value) Set(rv reflect.Value) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !rv.IsValid() {
+		return me.To(nil)
+	} else if !rv.CanInterface() {
+		return errors.Errorf("Set can not read the value of an unexported field")
+	} else if me.CanWrite && me.Kind != reflect.Slice && me.Kind != reflect.Interface && !me.nilled &&
+		(rv.Type() == me.Type || (rv.Type().AssignableTo(me.Type) && !isAtomic(me.Type))) {
+		me.WriteValue.Set(rv)
+		return checkEnum(me.WriteValue)
+	}
+	return me.To(rv.Interface())
+}
+
+// SetFrom coerces the current value of other into Value with the same rules as To(); when both are the same
+// type, other than slices which To() always copies, the value is assigned directly without first converting it
+// to an interface{}.  If other wraps a nil pointer then Value is set to its zero value.
+func (me *Value) SetFrom(other *Value) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if other == nil {
+		return errors.NilArgument("other")
+	}
+	return me.Set(other.WriteValue)
+}
+
+// MustTo is the same as To() except it panics with the error if To() returns one; it is intended for tests,
+// examples, and scripts where the coercion is known to succeed.
+func (me *Value) MustTo(arg interface{}) {
+	if err := me.To(arg); err != nil {
+		panic(err)
+	}
+}
+
+// TagValue returns the value of the struct-tag tagKey for the struct field fieldName; the second return
+// value is false if the field or the tag does not exist.
+//
+// Nested fields are specified by joining the field names with a period; fields promoted from embedded
+// structs can be specified with or without the embedded struct's name:
+//	type Address struct {
+//		City string `json:"city"`
+//	}
+//	type Person struct {
+//		Address Address `json:"address"`
+//	}
+//	v.TagValue("Address.City", "json") // returns "city", true
+func (me *Value) TagValue(fieldName, tagKey string) (string, bool) {
+	if me == nil || me.Type == nil {
+		return "", false
+	}
+	T := me.Type
+	var field reflect.StructField
+	for _, name := range strings.Split(fieldName, ".") {
+		for T.Kind() == reflect.Ptr {
+			T = T.Elem()
+		}
+		if T.Kind() != reflect.Struct {
+			return "", false
+		}
+		var ok bool
+		if field, ok = T.FieldByName(name); !ok {
+			return "", false
+		}
+		T = field.Type
+	}
+	return field.Tag.Lookup(tagKey)
+}
+
+// ToAppend is the same as To() except when Value is a slice the coerced elements are appended to the
+// existing slice instead of replacing it; the slice rules of To() still apply:
+//	var t []int
+//	set.V(&t).ToAppend("1")			// t is []int{ 1 }
+//	set.V(&t).ToAppend([]string{"2", "3"})	// t is []int{ 1, 2, 3 }
+//
+// Either all elements are appended or an error is returned and the slice is unaltered; if Options.SkipInvalidElems
+// is set then the elements that could be coerced are appended and an ElemErrors is returned.
+//
+// When Value is a map, arg is coerced into a map of the same type as if by To() and its entries are upserted into
+// the existing map; the map is unaltered if arg can not be coerced.
+func (me *Value) ToAppend(arg interface{}) error {
+	return me.contextError(me.toAppend(arg))
+}
+
+// toAppend is the implementation of ToAppend().
+func (me *Value) toAppend(arg interface{}) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite || (!me.IsSlice && !me.IsMap) {
+		return errors.Errorf(me.errorUnsupported("ToAppend"))
+	} else if me.IsMap {
+		entries := reflect.New(me.Type)
+		if err := me.v(entries).To(arg); err != nil {
+			return errors.Go(err)
+		}
+		me.mergeMap(entries.Elem())
+		return nil
+	}
+	tail := reflect.New(me.Type)
+	err := me.v(tail).To(arg)
+	if _, partial := err.(ElemErrors); err != nil && !partial {
+		return errors.Go(err)
+	}
+	me.WriteValue.Set(reflect.AppendSlice(me.WriteValue, tail.Elem()))
+	return err
+}
@@ -0,0 +1,191 @@
+package set_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+// benchmarkNumericSliceSize is the number of elements in the slices used by the numeric slice benchmarks.
+const benchmarkNumericSliceSize = 10000
+
+func BenchmarkValueToNumericSlice(b *testing.B) {
+	src := make([]int, benchmarkNumericSliceSize)
+	for k := range src {
+		src[k] = k
+	}
+	var dst []int64
+	v := set.V(&dst)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To(src); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueToNumericSliceElementwise(b *testing.B) {
+	// The elements are interface{} so To() must coerce each element individually; this is the
+	// baseline for BenchmarkValueToNumericSlice.
+	src := make([]interface{}, benchmarkNumericSliceSize)
+	for k := range src {
+		src[k] = k
+	}
+	var dst []int64
+	v := set.V(&dst)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To(src); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueToScalar(b *testing.B) {
+	var n int
+	v := set.V(&n)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To("42"); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueToScalarFromPointer(b *testing.B) {
+	var n float64
+	s := "3.14"
+	v := set.V(&n)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To(&s); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueNewElem(b *testing.B) {
+	type T struct {
+		A int
+		B string
+	}
+	var dst []T
+	v := set.V(&dst)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		elem, err := v.NewElem()
+		if err != nil {
+			b.Fatalf("During NewElem: %v", err.Error())
+		}
+		elem.WriteValue.Field(0).SetInt(int64(k))
+	}
+}
+
+func BenchmarkValueNewElemInto(b *testing.B) {
+	type T struct {
+		A int
+		B string
+	}
+	var dst []T
+	v := set.V(&dst)
+	elem := &set.Value{}
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.NewElemInto(elem); err != nil {
+			b.Fatalf("During NewElemInto: %v", err.Error())
+		}
+		elem.WriteValue.Field(0).SetInt(int64(k))
+	}
+}
+
+func BenchmarkValueToScalarNamed(b *testing.B) {
+	type Named string
+	var n Named
+	v := set.V(&n)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To("hello"); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueToScalarIntToFloat(b *testing.B) {
+	var f float32
+	v := set.V(&f)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := v.To(k); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueAppend(b *testing.B) {
+	items := make([]interface{}, 100)
+	for k := range items {
+		items[k] = k
+	}
+	var dst []int
+	v := set.V(&dst)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		dst = dst[:0]
+		if err := v.Append(items...); err != nil {
+			b.Fatalf("During Append: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueAppendReflect(b *testing.B) {
+	items := make([]reflect.Value, 100)
+	for k := range items {
+		items[k] = reflect.ValueOf(k)
+	}
+	var dst []int
+	v := set.V(&dst)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		dst = dst[:0]
+		if err := v.AppendReflect(items...); err != nil {
+			b.Fatalf("During AppendReflect: %v", err.Error())
+		}
+	}
+}
+
+func BenchmarkValueToSameType(b *testing.B) {
+	var n int
+	var s string
+	intValue, stringValue := set.V(&n), set.V(&s)
+	//
+	b.ResetTimer()
+	//
+	for k := 0; k < b.N; k++ {
+		if err := intValue.To(k); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		} else if err = stringValue.To("hello"); err != nil {
+			b.Fatalf("During To: %v", err.Error())
+		}
+	}
+}
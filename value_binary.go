@@ -0,0 +1,90 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"reflect"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+var (
+	typeBinaryMarshaler = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	typeGobEncoder      = reflect.TypeOf((*gob.GobEncoder)(nil)).Elem()
+)
+
+// MarshalBinary encodes the value wrapped by Value with encoding/gob so it can be stored in a byte-oriented
+// store such as a cache; UnmarshalBinary() decodes the bytes into a Value of the same type:
+//	data, err := set.V(&session).MarshalBinary()
+//	// ...
+//	var restored Session
+//	err = set.V(&restored).UnmarshalBinary(data)
+//
+// gob silently ignores struct fields that are functions or channels; MarshalBinary returns an error naming
+// such a field instead so values do not lose data on their way through the store.  Types stored in interface{}
+// fields must be registered with gob.Register().
+func (me *Value) MarshalBinary() ([]byte, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("MarshalBinary"))
+	} else if path, kind := gobUnsupported(me.Type, me.Type.String(), map[reflect.Type]bool{}); path != "" {
+		return nil, errors.Errorf("MarshalBinary can not encode %v; %v is a %v", me.Type, path, kind)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(me.WriteValue); err != nil {
+		return nil, errors.Errorf("MarshalBinary can not encode %v: %v", me.Type, err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data created by MarshalBinary() into the value wrapped by Value; either the value
+// is replaced entirely or an error is returned and the value is unaltered.
+func (me *Value) UnmarshalBinary(data []byte) error {
+	if me == nil {
+		return errors.NilReceiver()
+	} else if !me.CanWrite {
+		return errors.Errorf(me.errorUnsupported("UnmarshalBinary"))
+	}
+	decoded := reflect.New(me.Type)
+	if err := gob.NewDecoder(bytes.NewReader(data)).DecodeValue(decoded); err != nil {
+		return errors.Errorf("UnmarshalBinary can not decode %v: %v", me.Type, err.Error())
+	}
+	me.WriteValue.Set(decoded.Elem())
+	return nil
+}
+
+// gobUnsupported returns the path to, and the kind of, the first part of T that gob can not encode or silently
+// drops; path begins with name and an empty path is returned if T can be encoded.  Types that encode themselves
+// are not examined and visiting contains the types being examined so recursive types terminate.
+func gobUnsupported(T reflect.Type, name string, visiting map[reflect.Type]bool) (string, reflect.Kind) {
+	if visiting[T] {
+		return "", reflect.Invalid
+	} else if T.Implements(typeGobEncoder) || T.Implements(typeBinaryMarshaler) ||
+		reflect.PtrTo(T).Implements(typeGobEncoder) || reflect.PtrTo(T).Implements(typeBinaryMarshaler) {
+		return "", reflect.Invalid
+	}
+	visiting[T] = true
+	defer delete(visiting, T)
+	switch T.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return name, T.Kind()
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return gobUnsupported(T.Elem(), name, visiting)
+	case reflect.Map:
+		if path, kind := gobUnsupported(T.Key(), name, visiting); path != "" {
+			return path, kind
+		}
+		return gobUnsupported(T.Elem(), name, visiting)
+	case reflect.Struct:
+		for k, size := 0, T.NumField(); k < size; k++ {
+			if f := T.Field(k); f.PkgPath == "" {
+				if path, kind := gobUnsupported(f.Type, name+"."+f.Name, visiting); path != "" {
+					return path, kind
+				}
+			}
+		}
+	}
+	return "", reflect.Invalid
+}
@@ -0,0 +1,118 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestValue_marshalBinary(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+	}
+	type Session struct {
+		ID      int
+		User    string
+		Roles   []string
+		Expires time.Time
+		Home    *Address
+		Meta    map[string]float64
+	}
+	{ // Structs round-trip.
+		before := Session{
+			ID:      42,
+			User:    "bob",
+			Roles:   []string{"admin", "user"},
+			Expires: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			Home:    &Address{City: "Paris"},
+			Meta:    map[string]float64{"score": 9.5},
+		}
+		data, err := set.V(&before).MarshalBinary()
+		chk.NoError(err)
+		chk.NotEmpty(data)
+		var after Session
+		chk.NoError(set.V(&after).UnmarshalBinary(data))
+		chk.Equal(before, after)
+		//
+		data, err = set.V(before).MarshalBinary()
+		chk.NoError(err)
+		var ptr *Session
+		chk.NoError(set.V(&ptr).UnmarshalBinary(data))
+		chk.Equal(before, *ptr)
+	}
+	{ // Scalars and slices round-trip.
+		n := 42
+		data, err := set.V(&n).MarshalBinary()
+		chk.NoError(err)
+		var m int
+		chk.NoError(set.V(&m).UnmarshalBinary(data))
+		chk.Equal(42, m)
+		s := []string{"a", "b"}
+		data, err = set.V(s).MarshalBinary()
+		chk.NoError(err)
+		var r []string
+		chk.NoError(set.V(&r).UnmarshalBinary(data))
+		chk.Equal(s, r)
+	}
+	{ // Functions and channels are errors rather than silently dropped.
+		type Job struct {
+			Name string
+			Run  func()
+		}
+		_, err := set.V(&Job{Name: "a"}).MarshalBinary()
+		chk.Error(err)
+		chk.Contains(err.Error(), "Job.Run is a func")
+		type Queue struct {
+			Jobs []struct {
+				Done chan bool
+			}
+		}
+		_, err = set.V(&Queue{}).MarshalBinary()
+		chk.Error(err)
+		chk.Contains(err.Error(), ".Jobs.Done is a chan")
+		_, err = set.V(func() {}).MarshalBinary()
+		chk.Error(err)
+		type private struct {
+			run func()
+			N   int
+		}
+		_, err = set.V(private{N: 1}).MarshalBinary()
+		chk.NoError(err)
+	}
+	{ // Recursive types.
+		type Node struct {
+			Value int
+			Next  *Node
+		}
+		before := Node{Value: 1, Next: &Node{Value: 2}}
+		data, err := set.V(&before).MarshalBinary()
+		chk.NoError(err)
+		var after Node
+		chk.NoError(set.V(&after).UnmarshalBinary(data))
+		chk.Equal(before, after)
+	}
+	{ // Decode errors leave the value unaltered.
+		s := Session{ID: 1}
+		chk.Error(set.V(&s).UnmarshalBinary([]byte("not gob")))
+		chk.Equal(Session{ID: 1}, s)
+		data, err := set.V("hello").MarshalBinary()
+		chk.NoError(err)
+		chk.Error(set.V(&s).UnmarshalBinary(data))
+		chk.Equal(Session{ID: 1}, s)
+	}
+	{ // Unsupported.
+		var s Session
+		chk.Error(set.V(s).UnmarshalBinary(nil))
+		_, err := set.V(nil).MarshalBinary()
+		chk.Error(err)
+		var v *set.Value
+		_, err = v.MarshalBinary()
+		chk.Error(err)
+		chk.Error(v.UnmarshalBinary(nil))
+	}
+}
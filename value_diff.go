@@ -0,0 +1,138 @@
+package set
+
+import (
+	"reflect"
+
+	"github.com/nofeaturesonlybugs/errors"
+)
+
+// FieldDiff describes a struct field whose value differs between two values of the same struct type.
+type FieldDiff struct {
+	// Path is the name of the field; the names of nested fields are joined with a period, e.g. "Address.City".
+	Path string
+	// Old and New are the values of the field in the receiver and the argument of Diff(); a nil pointer is
+	// reported as nil.
+	Old, New interface{}
+}
+
+// FieldChange describes a field that Value.FillDiff() would change; it is the same as FieldDiff.
+type FieldChange = FieldDiff
+
+// Diff compares the struct wrapped by Value with the struct wrapped by other and returns the fields whose
+// values differ in declaration order.  Both values must be the same struct type.
+//
+// Fields that are structs, or pointers to structs, are compared field by field so only the changed leaves are
+// returned; all other fields, including atomic types such as time.Time, are compared with reflect.DeepEqual.
+// Unexported fields are skipped and nil pointers are never instantiated.
+func (me *Value) Diff(other *Value) ([]FieldDiff, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if other == nil {
+		return nil, errors.NilArgument("other")
+	} else if me.Kind != reflect.Struct || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("Diff"))
+	} else if other.Type != me.Type || !other.WriteValue.IsValid() {
+		return nil, errors.Errorf("Diff expects the same struct type; got %v and %v", me.Type, other.Type)
+	}
+	return diffFields(me.WriteValue, other.WriteValue, "", nil), nil
+}
+
+// diffFields appends the differences between the structs a and b to rv; prefix is prepended to every path.
+func diffFields(a, b reflect.Value, prefix string, rv []FieldDiff) []FieldDiff {
+	T := a.Type()
+	for k, size := 0, T.NumField(); k < size; k++ {
+		field := T.Field(k)
+		if field.PkgPath != "" {
+			continue
+		}
+		path := prefix + field.Name
+		fa, fb := indirect(a.Field(k)), indirect(b.Field(k))
+		switch {
+		case !fa.IsValid() && !fb.IsValid():
+		case !fa.IsValid():
+			rv = append(rv, FieldDiff{Path: path, New: fb.Interface()})
+		case !fb.IsValid():
+			rv = append(rv, FieldDiff{Path: path, Old: fa.Interface()})
+		case fa.Kind() == reflect.Struct && !isAtomic(fa.Type()):
+			rv = diffFields(fa, fb, path+".", rv)
+		default:
+			if old, updated := fa.Interface(), fb.Interface(); !reflect.DeepEqual(old, updated) {
+				rv = append(rv, FieldDiff{Path: path, Old: old, New: updated})
+			}
+		}
+	}
+	return rv
+}
+
+// FillDiff reports the fields that Fill() would change without altering the struct wrapped by Value; Old is the
+// current value of each field and New is the value Fill() would assign.  The struct is deep copied, the copy is
+// filled with getter and opts, and the copy is compared with Diff().
+//
+// Fill errors are returned as-is and Validator is honored the same as for Fill().
+func (me *Value) FillDiff(getter Getter, opts ...FillOption) ([]FieldChange, error) {
+	if me == nil {
+		return nil, errors.NilReceiver()
+	} else if me.Kind != reflect.Struct || !me.WriteValue.IsValid() {
+		return nil, errors.Errorf(me.errorUnsupported("FillDiff"))
+	}
+	copied := me.v(reflect.New(me.Type))
+	copied.WriteValue.Set(deepCopy(me.WriteValue, map[uintptr]reflect.Value{}))
+	if err := copied.Fill(getter, opts...); err != nil {
+		return nil, errors.Go(err)
+	}
+	return me.Diff(copied)
+}
+
+// deepCopy returns a copy of v that shares no pointers, slices, or maps with v; visited maps the pointers already
+// copied to their copies so cycles are preserved rather than followed forever.  Unexported fields are copied
+// shallowly.
+func deepCopy(v reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		} else if copied, ok := visited[v.Pointer()]; ok && copied.Type() == v.Type() {
+			return copied
+		}
+		rv := reflect.New(v.Type().Elem())
+		visited[v.Pointer()] = rv
+		rv.Elem().Set(deepCopy(v.Elem(), visited))
+		return rv
+	case reflect.Struct:
+		rv := reflect.New(v.Type()).Elem()
+		rv.Set(v)
+		for k, size := 0, v.NumField(); k < size; k++ {
+			if field := v.Field(k); field.CanInterface() {
+				rv.Field(k).Set(deepCopy(field, visited))
+			}
+		}
+		return rv
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		rv := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for k, size := 0, v.Len(); k < size; k++ {
+			rv.Index(k).Set(deepCopy(v.Index(k), visited))
+		}
+		return rv
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		rv := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			rv.SetMapIndex(iter.Key(), deepCopy(iter.Value(), visited))
+		}
+		return rv
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		rv := reflect.New(v.Type()).Elem()
+		rv.Set(deepCopy(v.Elem(), visited))
+		return rv
+	}
+	return v
+}
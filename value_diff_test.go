@@ -0,0 +1,126 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestValue_diff(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+		Zip  string
+	}
+	type Person struct {
+		Name    string
+		Tags    []string
+		Born    time.Time
+		Address Address
+		Work    *Address
+		private int
+	}
+	born := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := Person{Name: "bob", Tags: []string{"a"}, Born: born, Address: Address{"Paris", "75001"}, private: 1}
+	after := before
+	after.Tags = []string{"a"}
+	after.Address.City = "Lyon"
+	after.Work = &Address{City: "Nice"}
+	after.private = 2
+	//
+	diffs, err := set.V(&before).Diff(set.V(&after))
+	chk.NoError(err)
+	chk.Equal([]set.FieldDiff{
+		{Path: "Address.City", Old: "Paris", New: "Lyon"},
+		{Path: "Work", Old: nil, New: Address{City: "Nice"}},
+	}, diffs)
+	chk.Nil(before.Work) // Not instantiated.
+	//
+	after.Born = born.Add(time.Hour)
+	before.Work = &Address{City: "Nice", Zip: "06000"}
+	diffs, err = set.V(before).Diff(set.V(after))
+	chk.NoError(err)
+	chk.Equal([]set.FieldDiff{
+		{Path: "Born", Old: born, New: born.Add(time.Hour)},
+		{Path: "Address.City", Old: "Paris", New: "Lyon"},
+		{Path: "Work.Zip", Old: "06000", New: ""},
+	}, diffs)
+	//
+	diffs, err = set.V(before).Diff(set.V(before))
+	chk.NoError(err)
+	chk.Nil(diffs)
+	{ // Errors
+		var v *set.Value
+		_, err = v.Diff(set.V(before))
+		chk.Error(err)
+		_, err = set.V(before).Diff(nil)
+		chk.Error(err)
+		_, err = set.V(42).Diff(set.V(42))
+		chk.Error(err)
+		_, err = set.V(before).Diff(set.V(Address{}))
+		chk.Error(err)
+	}
+}
+
+func TestValue_fillDiff(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Tags    []string
+		Home    *Address
+		Visited map[string]int
+	}
+	current := Person{Name: "bob", Age: 42, Tags: []string{"a"}, Home: &Address{"Paris"}, Visited: map[string]int{"x": 1}}
+	changes, err := set.V(&current).FillDiff(set.MapGetter(map[string]interface{}{
+		"Name":    "bob",
+		"Age":     "43",
+		"Tags":    []string{"a", "b"},
+		"Home":    map[string]interface{}{"City": "Lyon"},
+	}))
+	chk.NoError(err)
+	chk.Equal([]set.FieldChange{
+		{Path: "Age", Old: 42, New: 43},
+		{Path: "Tags", Old: []string{"a"}, New: []string{"a", "b"}},
+		{Path: "Home.City", Old: "Paris", New: "Lyon"},
+		{Path: "Visited", Old: map[string]int{"x": 1}, New: map[string]int(nil)}, // Fill() zeroes fields missing from the Getter.
+	}, changes)
+	// Nothing was mutated.
+	chk.Equal(Person{Name: "bob", Age: 42, Tags: []string{"a"}, Home: &Address{"Paris"}, Visited: map[string]int{"x": 1}}, current)
+	//
+	_, err = set.V(&current).FillDiff(set.MapGetter(map[string]interface{}{"Age": "old"}))
+	chk.Error(err)
+	chk.Equal(42, current.Age)
+	{
+		var v *set.Value
+		_, err = v.FillDiff(set.MapGetter(map[string]interface{}{}))
+		chk.Error(err)
+		_, err = set.V(42).FillDiff(set.MapGetter(map[string]interface{}{}))
+		chk.Error(err)
+	}
+}
+
+func TestValue_fillDiffCycles(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	a := &Node{Name: "a"}
+	a.Next = a
+	changes, err := set.V(a).FillDiff(set.MapGetter(map[string]interface{}{"Name": "b"}))
+	chk.NoError(err)
+	chk.NotEmpty(changes)
+	chk.Equal(set.FieldChange{Path: "Name", Old: "a", New: "b"}, changes[0])
+	chk.Equal("a", a.Name)
+	chk.Equal(a, a.Next)
+}
@@ -0,0 +1,97 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// valueStringMax is the maximum number of characters of a string or fmt.Stringer shown by Value.String().
+const valueStringMax = 32
+
+// String returns a description of Value for debugging; it contains the wrapped type, kind, CanWrite, the
+// element type for slices, arrays, and maps, and a short rendering of the current value:
+//	set.Value{Type: []string, Kind: slice, CanWrite: true, Elem: string, Value: [len=2]}
+//
+// Strings longer than 32 characters are truncated and the contents of structs, slices, arrays, and maps are
+// summarized rather than rendered so String() is safe to call on very large values.
+func (me *Value) String() string {
+	if me == nil {
+		return "set.Value(nil)"
+	}
+	var b strings.Builder
+	b.WriteString("set.Value{Type: ")
+	if me.Type == nil {
+		b.WriteString("<nil>")
+	} else {
+		b.WriteString(me.Type.String())
+	}
+	b.WriteString(", Kind: ")
+	b.WriteString(me.Kind.String())
+	b.WriteString(", CanWrite: ")
+	b.WriteString(strconv.FormatBool(me.CanWrite))
+	if me.IsSlice || me.IsMap || me.IsArray {
+		b.WriteString(", Elem: ")
+		b.WriteString(me.ElemType.String())
+	}
+	b.WriteString(", Value: ")
+	b.WriteString(renderValue(me.WriteValue))
+	b.WriteString("}")
+	return b.String()
+}
+
+// renderValue returns a short rendering of v for Value.String().
+func renderValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return "<nil>"
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.String:
+		return strconv.Quote(truncate(v.String()))
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		return "map[len=" + strconv.Itoa(v.Len()) + "]"
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+		return "[len=" + strconv.Itoa(v.Len()) + "]"
+	case reflect.Array:
+		return "[len=" + strconv.Itoa(v.Len()) + "]"
+	case reflect.Struct:
+		if v.CanInterface() {
+			if stringer, ok := v.Interface().(fmt.Stringer); ok {
+				return truncate(stringer.String())
+			}
+		}
+		return "{fields=" + strconv.Itoa(v.NumField()) + "}"
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return v.Type().String()
+	}
+	return v.Type().String()
+}
+
+// truncate shortens s to valueStringMax characters.
+func truncate(s string) string {
+	if len(s) <= valueStringMax {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= valueStringMax {
+		return s
+	}
+	return string(runes[:valueStringMax]) + "..."
+}
@@ -0,0 +1,50 @@
+package set_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/set"
+)
+
+func TestValue_string(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A, B int
+	}
+	var nilValue *set.Value
+	i, f, b := 42, 3.5, true
+	var ip *int
+	s, long := "hello", strings.Repeat("a", 40)
+	var nilSlice []string
+	slice := []string{"a", "b"}
+	m := map[string]int{"a": 1}
+	when := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, test := range []struct {
+		Value  *set.Value
+		Expect string
+	}{
+		{nilValue, "set.Value(nil)"},
+		{set.V(nil), "set.Value{Type: <nil>, Kind: invalid, CanWrite: false, Value: <nil>}"},
+		{set.V(&i), "set.Value{Type: int, Kind: int, CanWrite: true, Value: 42}"},
+		{set.V(i), "set.Value{Type: int, Kind: int, CanWrite: false, Value: 42}"},
+		{set.V(&f), "set.Value{Type: float64, Kind: float64, CanWrite: true, Value: 3.5}"},
+		{set.V(&b), "set.Value{Type: bool, Kind: bool, CanWrite: true, Value: true}"},
+		{set.V(ip), "set.Value{Type: int, Kind: int, CanWrite: false, Value: <nil>}"},
+		{set.V(&s), `set.Value{Type: string, Kind: string, CanWrite: true, Value: "hello"}`},
+		{set.V(&long), `set.Value{Type: string, Kind: string, CanWrite: true, Value: "` + strings.Repeat("a", 32) + `..."}`},
+		{set.V(&nilSlice), "set.Value{Type: []string, Kind: slice, CanWrite: true, Elem: string, Value: nil}"},
+		{set.V(&slice), "set.Value{Type: []string, Kind: slice, CanWrite: true, Elem: string, Value: [len=2]}"},
+		{set.V(&m), "set.Value{Type: map[string]int, Kind: map, CanWrite: true, Elem: int, Value: map[len=1]}"},
+		{set.V(&T{}), "set.Value{Type: set_test.T, Kind: struct, CanWrite: true, Value: {fields=2}}"},
+		{set.V(&when), "set.Value{Type: time.Time, Kind: struct, CanWrite: true, Value: 2021-01-02 03:04:05 +0000 UTC}"},
+	} {
+		chk.Equal(test.Expect, test.Value.String())
+	}
+	chk.Equal("set.Value{Type: int, Kind: int, CanWrite: true, Value: 42}", fmt.Sprintf("%v", set.V(&i)))
+}
@@ -1,9 +1,20 @@
 package set_test
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unsafe"
 
+	"github.com/nofeaturesonlybugs/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nofeaturesonlybugs/set"
@@ -325,6 +336,73 @@ func TestValue_setPointers(t *testing.T) {
 	}
 }
 
+func TestValue_setPointersSameType(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type S struct {
+		A int
+	}
+	{ // *int source into int
+		var i int
+		p := 42
+		chk.NoError(set.V(&i).To(&p))
+		chk.Equal(42, i)
+		p = 0 // Not aliased.
+		chk.Equal(42, i)
+	}
+	{ // int source into *int
+		var ip *int
+		chk.NoError(set.V(&ip).To(42))
+		chk.NotNil(ip)
+		chk.Equal(42, *ip)
+	}
+	{ // *int source into *int allocated by the destination.
+		var ip *int
+		p := 42
+		chk.NoError(set.V(&ip).To(&p))
+		chk.Equal(42, *ip)
+		chk.False(ip == &p)
+	}
+	{ // ***int source into **int
+		var ipp **int
+		p := 42
+		pp := &p
+		chk.NoError(set.V(&ipp).To(&pp))
+		chk.Equal(42, **ipp)
+	}
+	{ // Different widths of the same kind.
+		var i int
+		var f float32
+		var u uint16
+		var b bool
+		i8, f64, u64, bt := int8(-8), float64(1.5), uint64(16), true
+		chk.NoError(set.V(&i).To(&i8))
+		chk.NoError(set.V(&f).To(&f64))
+		chk.NoError(set.V(&u).To(&u64))
+		chk.NoError(set.V(&b).To(&bt))
+		chk.Equal(-8, i)
+		chk.Equal(float32(1.5), f)
+		chk.Equal(uint16(16), u)
+		chk.Equal(true, b)
+	}
+	{ // *S source into S
+		var s S
+		chk.NoError(set.V(&s).To(&S{A: 3}))
+		chk.Equal(S{A: 3}, s)
+	}
+	{ // S source into *S
+		var sp *S
+		chk.NoError(set.V(&sp).To(S{A: 4}))
+		chk.Equal(&S{A: 4}, sp)
+	}
+	{ // **S source into **S
+		var spp **S
+		src := &S{A: 5}
+		chk.NoError(set.V(&spp).To(&src))
+		chk.Equal(S{A: 5}, **spp)
+	}
+}
+
 func TestValue_setSlice(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -519,6 +597,78 @@ func TestValue_setSliceToString(t *testing.T) {
 		chk.Equal("64", s)
 	}
 }
+func TestValue_toAppend(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var s []int
+		v := set.V(&s)
+		chk.NoError(v.ToAppend("1"))
+		chk.Equal([]int{1}, s)
+		chk.NoError(v.ToAppend([]string{"2", "3"}))
+		chk.Equal([]int{1, 2, 3}, s)
+		chk.NoError(v.ToAppend([]interface{}{4.5, uint8(5)}))
+		chk.Equal([]int{1, 2, 3, 4, 5}, s)
+		chk.NoError(v.ToAppend(nil))
+		chk.Equal([]int{1, 2, 3, 4, 5}, s)
+		// All or nothing.
+		chk.Error(v.ToAppend([]string{"6", "Hello"}))
+		chk.Equal([]int{1, 2, 3, 4, 5}, s)
+	}
+	{ // Pointers to slices.
+		var s *[]string
+		chk.NoError(set.V(&s).ToAppend([]int{1, 2}))
+		chk.NoError(set.V(&s).ToAppend(true))
+		chk.Equal([]string{"1", "2", "true"}, *s)
+	}
+	{ // Not a slice or not writable.
+		var i int
+		chk.Error(set.V(&i).ToAppend(1))
+		var s []int
+		chk.Error(set.V(s).ToAppend(1))
+		var v *set.Value
+		chk.Error(v.ToAppend(1))
+	}
+}
+
+func TestValue_setMap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var m map[string]int
+		err := set.V(&m).To(map[interface{}]string{"a": "1", "b": "2"})
+		chk.NoError(err)
+		chk.Equal(map[string]int{"a": 1, "b": 2}, m)
+	}
+	{
+		var m map[int]*bool
+		err := set.V(&m).To(map[string]interface{}{"1": "true", "0": 0})
+		chk.NoError(err)
+		chk.Equal(2, len(m))
+		chk.True(*m[1])
+		chk.False(*m[0])
+	}
+	{ // Key errors.
+		m := map[int]string{1: "one"}
+		err := set.V(&m).To(map[string]string{"one": "one"})
+		chk.Error(err)
+		chk.Nil(m)
+	}
+	{ // Element errors.
+		m := map[string]int{"one": 1}
+		err := set.V(&m).To(map[string]string{"one": "one"})
+		chk.Error(err)
+		chk.Nil(m)
+	}
+	{ // Same type is assigned.
+		var m map[string]int
+		src := map[string]int{"a": 1}
+		err := set.V(&m).To(src)
+		chk.NoError(err)
+		chk.Equal(src, m)
+	}
+}
+
 func TestValue_setSliceCreatesCopies(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -639,6 +789,178 @@ func TestValue_zero(t *testing.T) {
 	}
 }
 
+func TestValue_isZero(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		Num   int
+		Inner Inner
+	}
+	isZero := func(v *set.Value) bool {
+		zero, err := v.IsZero()
+		chk.NoError(err)
+		return zero
+	}
+	{
+		var v *set.Value
+		_, err := v.IsZero()
+		chk.Error(err)
+		chk.True(isZero(set.V(nil)))
+	}
+	{
+		var i int
+		chk.True(isZero(set.V(&i)))
+		i = 42
+		chk.False(isZero(set.V(&i)))
+		chk.False(isZero(set.V(i)))
+	}
+	{
+		var s []int
+		chk.True(isZero(set.V(&s)))
+		s = []int{}
+		chk.False(isZero(set.V(&s)))
+	}
+	{
+		var m map[string]int
+		chk.True(isZero(set.V(&m)))
+		m = map[string]int{}
+		chk.False(isZero(set.V(&m)))
+	}
+	{
+		var ip *int
+		chk.True(isZero(set.V(ip)))
+		i := 0
+		ip = &i
+		chk.True(isZero(set.V(ip)))
+		i = 1
+		chk.False(isZero(set.V(ip)))
+	}
+	{
+		var o Outer
+		chk.True(isZero(set.V(&o)))
+		o.Inner.Name = "Bob"
+		chk.False(isZero(set.V(&o)))
+		chk.False(isZero(set.V(o)))
+		o = Outer{}
+		chk.True(isZero(set.V(o)))
+	}
+}
+
+func TestValue_typeName(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Named struct{}
+	{
+		var v *set.Value
+		chk.Equal("", v.TypeName())
+		chk.Equal("", v.PkgPath())
+		chk.Equal("", set.V(nil).TypeName())
+		chk.Equal("", set.V(nil).PkgPath())
+	}
+	{
+		var n Named
+		chk.Equal("Named", set.V(&n).TypeName())
+		chk.Equal("github.com/nofeaturesonlybugs/set_test", set.V(&n).PkgPath())
+	}
+	{
+		var i int
+		chk.Equal("int", set.V(&i).TypeName())
+		chk.Equal("", set.V(&i).PkgPath())
+	}
+	{
+		var anon struct{ A int }
+		chk.Equal("", set.V(&anon).TypeName())
+		chk.Equal("", set.V(&anon).PkgPath())
+	}
+	{ // Pointers are followed to the final type.
+		var n **Named
+		v := set.V(&n)
+		chk.Equal("Named", v.TypeName())
+		chk.Equal("github.com/nofeaturesonlybugs/set_test", v.PkgPath())
+		chk.Equal(reflect.Struct, v.Kind)
+	}
+	{
+		var s []Named
+		chk.Equal("", set.V(&s).TypeName())
+		chk.Equal(reflect.Slice, set.V(&s).Kind)
+		var tm time.Time
+		chk.Equal("Time", set.V(&tm).TypeName())
+		chk.Equal("time", set.V(&tm).PkgPath())
+	}
+}
+
+func TestValue_mapKeys(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Color string
+	type Point struct{ X, Y int }
+	{
+		m := map[string]int{"c": 3, "a": 1, "b": 2, "B": 0}
+		keys, err := set.V(m).MapKeys()
+		chk.NoError(err)
+		chk.ElementsMatch([]interface{}{"a", "b", "c", "B"}, keys)
+		for k := 0; k < 10; k++ {
+			keys, err = set.V(&m).MapKeysSorted()
+			chk.NoError(err)
+			chk.Equal([]interface{}{"B", "a", "b", "c"}, keys)
+		}
+	}
+	{
+		m := map[int]bool{10: true, -5: true, 2: false, 100: true}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Equal([]interface{}{-5, 2, 10, 100}, keys)
+	}
+	{
+		m := map[uint8]bool{10: true, 5: true, 255: false}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Equal([]interface{}{uint8(5), uint8(10), uint8(255)}, keys)
+	}
+	{
+		m := map[float64]bool{1.5: true, -0.5: true, 1.25: false}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Equal([]interface{}{-0.5, 1.25, 1.5}, keys)
+	}
+	{
+		m := map[bool]int{true: 1, false: 0}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Equal([]interface{}{false, true}, keys)
+	}
+	{ // Named types sort by their kind.
+		m := map[Color]int{"red": 1, "blue": 2, "green": 3}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Equal([]interface{}{Color("blue"), Color("green"), Color("red")}, keys)
+	}
+	{ // Unordered key types are returned unsorted.
+		m := map[Point]int{{1, 2}: 1, {0, 0}: 2}
+		keys, err := set.V(m).MapKeysSorted()
+		chk.NoError(err)
+		chk.ElementsMatch([]interface{}{Point{1, 2}, Point{0, 0}}, keys)
+	}
+	{
+		var m map[string]int
+		keys, err := set.V(&m).MapKeysSorted()
+		chk.NoError(err)
+		chk.Empty(keys)
+	}
+	{
+		var v *set.Value
+		_, err := v.MapKeys()
+		chk.Error(err)
+		_, err = v.MapKeysSorted()
+		chk.Error(err)
+		_, err = set.V([]int{}).MapKeysSorted()
+		chk.Error(err)
+	}
+}
+
 func TestValue_append(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -745,6 +1067,138 @@ func TestValue_fill(t *testing.T) {
 	}
 }
 
+func TestValue_fillByTags(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var err error
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `db:"zip_code" json:"zip"`
+	}
+	type T struct {
+		ID      int    `db:"id" json:"json_id"`
+		Name    string `json:"name,omitempty"`
+		Age     uint   `db:",omitempty" json:"age"`
+		Email   string `db:"email,omitempty"`
+		Other   string
+		Address Address `json:"address"`
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"id":      "42",
+		"json_id": "13",
+		"name":    "Bob",
+		"age":     30,
+		"email":   "bob@example.com",
+		"Other":   "other",
+		"address": map[string]interface{}{
+			"city":     "Big City",
+			"zip_code": "12345",
+			"zip":      "54321",
+		},
+	})
+	{
+		var t T
+		err = set.V(&t).FillByTags([]string{"db", "json"}, getter)
+		chk.NoError(err)
+		chk.Equal(42, t.ID)
+		chk.Equal("Bob", t.Name)
+		chk.Equal(uint(30), t.Age)
+		chk.Equal("bob@example.com", t.Email)
+		chk.Equal("other", t.Other)
+		chk.Equal("Big City", t.Address.City)
+		chk.Equal("12345", t.Address.Zip)
+	}
+	{
+		var t T
+		err = set.V(&t).FillByTags([]string{"json", "db"}, getter)
+		chk.NoError(err)
+		chk.Equal(13, t.ID)
+		chk.Equal("bob@example.com", t.Email)
+		chk.Equal("54321", t.Address.Zip)
+	}
+	{
+		var t T
+		err = set.V(&t).FillByTags(nil, getter)
+		chk.NoError(err)
+		chk.Equal(0, t.ID)
+		chk.Equal("other", t.Other)
+	}
+	{ // The first tag with a name wins; tags without a name fall back to the next key and then the field name.
+		type U struct {
+			A string `db:"a" json:"-"`
+			B string `db:"-" json:"b"`
+			C string `db:",omitempty"`
+			D string `db:",omitempty" json:"d"`
+		}
+		getter := set.MapGetter(map[string]interface{}{"a": "a", "b": "b", "B": "B", "C": "c", "d": "d"})
+		var u U
+		chk.NoError(set.V(&u).FillByTags([]string{"db", "json"}, getter))
+		chk.Equal(U{A: "a", C: "c", D: "d"}, u)
+		u = U{}
+		chk.NoError(set.V(&u).FillByTags([]string{"json", "db"}, getter))
+		chk.Equal(U{B: "b", C: "c", D: "d"}, u)
+	}
+}
+
+func TestValue_fillStream(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+	}
+	type Person struct {
+		Name    string
+		Age     uint
+		Address Address
+	}
+	{
+		stream := `{"Name": "Bob", "Age": 42, "Address": {"City": "Big City"}}
+			{"Name": "Alice", "Age": "37"}
+			{"Name": "Carol"}`
+		var people []Person
+		err := set.V(&people).FillStream(json.NewDecoder(strings.NewReader(stream)))
+		chk.NoError(err)
+		chk.Equal([]Person{
+			{Name: "Bob", Age: 42, Address: Address{City: "Big City"}},
+			{Name: "Alice", Age: 37},
+			{Name: "Carol"},
+		}, people)
+	}
+	{ // Pointer elements.
+		stream := `{"Name": "Bob"} {"Name": "Alice"}`
+		var people []*Person
+		err := set.V(&people).FillStream(json.NewDecoder(strings.NewReader(stream)))
+		chk.NoError(err)
+		chk.Equal(2, len(people))
+		chk.Equal("Bob", people[0].Name)
+		chk.Equal("Alice", people[1].Name)
+	}
+	{ // Malformed input.
+		stream := `{"Name": "Bob"} {"Name": ` + "\n"
+		var people []Person
+		err := set.V(&people).FillStream(json.NewDecoder(strings.NewReader(stream)))
+		chk.Error(err)
+		chk.Contains(err.Error(), "record 1")
+		chk.Equal(1, len(people))
+	}
+	{ // Fill errors.
+		stream := `{"Name": "Bob"} {"Name": "Alice"} {"Age": "old"}`
+		var people []Person
+		err := set.V(&people).FillStream(json.NewDecoder(strings.NewReader(stream)))
+		chk.Error(err)
+		chk.Contains(err.Error(), "record 2")
+		chk.Equal(2, len(people))
+	}
+	{ // Unsupported.
+		var v *set.Value
+		chk.Error(v.FillStream(json.NewDecoder(strings.NewReader(""))))
+		var people []Person
+		chk.Error(set.V(people).FillStream(json.NewDecoder(strings.NewReader(""))))
+		var names []string
+		chk.Error(set.V(&names).FillStream(json.NewDecoder(strings.NewReader(""))))
+	}
+}
+
 func TestValue_fillNonStruct(t *testing.T) {
 	chk := assert.New(t)
 	//
@@ -1414,6 +1868,9 @@ func TestValue_fieldByIndex(t *testing.T) {
 		field, err = outer.FieldByIndexAsValue([]int{0, 0, 4})
 		chk.Nil(field)
 		chk.Error(err)
+		field, err = outer.FieldByIndexAsValue([]int{0, 0, 3})
+		chk.Nil(field)
+		chk.Error(err)
 		field, err = outer.FieldByIndexAsValue([]int{0, 6})
 		chk.Nil(field)
 		chk.Error(err)
@@ -1423,6 +1880,202 @@ func TestValue_fieldByIndex(t *testing.T) {
 	}
 }
 
+func TestValue_deepFields(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Geo struct {
+		Lat, Lng float64
+	}
+	type Address struct {
+		City string
+		Geo  *Geo
+	}
+	type Base struct {
+		ID int
+	}
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type T struct {
+		Base
+		Name    string
+		Address Address
+		Work    *Address
+		Born    time.Time
+		Tags    []string
+		Meta    map[string]string
+		Node    Node
+		hidden  string
+	}
+	paths := func(fields []set.FlatField) []string {
+		var rv []string
+		for _, f := range fields {
+			rv = append(rv, f.Path)
+		}
+		return rv
+	}
+	{
+		var t T
+		fields := set.V(&t).DeepFields()
+		chk.Equal([]string{
+			"ID", "Name", "Address.City", "Address.Geo.Lat", "Address.Geo.Lng", "Work.City", "Work.Geo.Lat",
+			"Work.Geo.Lng", "Born", "Tags", "Meta", "Node.Name", "Node.Next",
+		}, paths(fields))
+		chk.Equal("Lat", fields[3].Field.Name)
+		for _, f := range fields {
+			if f.Value.IsScalar {
+				chk.NoError(f.Value.To("42"))
+			}
+		}
+		chk.Equal(42, t.ID)
+		chk.Equal("42", t.Address.City)
+		chk.Equal(42.0, t.Address.Geo.Lat)
+		chk.Equal(42.0, t.Work.Geo.Lng)
+		chk.Equal("42", t.Node.Name)
+		chk.Equal("", t.hidden)
+	}
+	{ // Nil pointers can not be traversed when the Value is not writable.
+		fields := set.V(T{}).DeepFields()
+		chk.Contains(paths(fields), "Work")
+		chk.Contains(paths(fields), "Address.Geo")
+	}
+	{
+		var b bool
+		chk.Nil(set.V(&b).DeepFields())
+		var v *set.Value
+		chk.Nil(v.DeepFields())
+	}
+}
+
+func TestValue_fieldsFlattened(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Base struct {
+		ID      int
+		Created string
+	}
+	type Audit struct {
+		Created string
+		Updated string
+		By      string
+	}
+	type Other struct {
+		By string
+	}
+	type T struct {
+		*Base
+		Audit
+		*Other
+		Name    string
+		Updated string
+	}
+	names := func(fields []set.Field) []string {
+		var rv []string
+		for _, f := range fields {
+			rv = append(rv, f.Field.Name)
+		}
+		return rv
+	}
+	{
+		var t T
+		v := set.V(&t)
+		fields := v.FieldsFlattened()
+		// Created is ambiguous between Base and Audit, By is ambiguous between Audit and Other, and
+		// Audit.Updated is shadowed by T.Updated.
+		chk.Equal([]string{"ID", "Name", "Updated"}, names(fields))
+		chk.NotNil(t.Base)
+		chk.Nil(t.Other) // No fields are promoted through Other.
+		chk.Equal([]int{0, 0}, fields[0].Field.Index)
+		chk.Equal([]int{3}, fields[1].Field.Index)
+		chk.Equal([]int{4}, fields[2].Field.Index)
+		for _, f := range fields {
+			chk.NoError(f.Value.To("42"))
+		}
+		chk.Equal(42, t.ID)
+		chk.Equal("42", t.Name)
+		chk.Equal("42", t.Updated)
+		chk.Equal("", t.Audit.Updated)
+	}
+	{ // Index paths can be used with FieldByIndex
+		var t T
+		v := set.V(&t)
+		fields := set.V(T{}).FieldsFlattened()
+		chk.Equal([]string{"Name", "Updated"}, names(fields)) // Nil pointers can not be traversed.
+		for _, f := range set.V(&T{}).FieldsFlattened() {
+			field, err := v.FieldByIndex(f.Field.Index)
+			chk.NoError(err)
+			chk.NoError(set.V(field).To("13"))
+		}
+		chk.Equal(13, t.ID)
+		chk.Equal("13", t.Name)
+	}
+	{ // Embedded pointers to the same type do not recurse forever.
+		type Node struct {
+			*Node
+			Value int
+		}
+		var n Node
+		fields := set.V(&n).FieldsFlattened()
+		chk.Equal([]string{"Value"}, names(fields))
+	}
+	{
+		var b bool
+		chk.Nil(set.V(&b).FieldsFlattened())
+	}
+}
+
+func TestValue_tagValue(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Base struct {
+		ID int `json:"id"`
+	}
+	type Address struct {
+		City string `json:"city" db:""`
+	}
+	type Person struct {
+		Base
+		Name    string   `json:"name,omitempty"`
+		Age     int      `db:"age"`
+		Address *Address `json:"address"`
+		Count   int
+	}
+	var p Person
+	v := set.V(&p)
+	for _, test := range []struct {
+		Field, Tag string
+		Expect     string
+		OK         bool
+	}{
+		{"Name", "json", "name,omitempty", true},
+		{"Age", "json", "", false},
+		{"Age", "db", "age", true},
+		{"Count", "json", "", false},
+		{"Missing", "json", "", false},
+		{"Address", "json", "address", true},
+		{"Address.City", "json", "city", true},
+		{"Address.City", "db", "", true},
+		{"Address.Missing", "json", "", false},
+		{"Name.Missing", "json", "", false},
+		{"ID", "json", "id", true},
+		{"Base.ID", "json", "id", true},
+	} {
+		value, ok := v.TagValue(test.Field, test.Tag)
+		chk.Equal(test.Expect, value, test.Field)
+		chk.Equal(test.OK, ok, test.Field)
+	}
+	{
+		var v *set.Value
+		value, ok := v.TagValue("Name", "json")
+		chk.Equal("", value)
+		chk.False(ok)
+		value, ok = set.V(nil).TagValue("Name", "json")
+		chk.Equal("", value)
+		chk.False(ok)
+	}
+}
+
 func TestValue_fieldByIndexCoverageErrors(t *testing.T) {
 	chk := assert.New(t)
 	var err error
@@ -1614,3 +2267,2250 @@ func TestValue_newElemCodeCoverage(t *testing.T) {
 		chk.Nil(elem)
 	}
 }
+
+func TestValue_setZeroIf(t *testing.T) {
+	chk := assert.New(t)
+	//
+	blank := func(v *set.Value) bool {
+		return v.Kind == reflect.String && strings.TrimSpace(v.WriteValue.String()) == ""
+	}
+	{ // Scalars.
+		s := "   "
+		chk.NoError(set.V(&s).SetZeroIf(blank))
+		chk.Equal("", s)
+		s = " x "
+		chk.NoError(set.V(&s).SetZeroIf(blank))
+		chk.Equal(" x ", s)
+	}
+	{ // Structs are visited field-wise.
+		type Address struct {
+			Street, City string
+		}
+		type Person struct {
+			Name     string
+			Nick     string
+			Age      int
+			Address  Address
+			Previous *Address
+			Missing  *Address
+			When     time.Time
+			private  string
+		}
+		p := Person{
+			Name:     "Bob",
+			Nick:     " \t",
+			Age:      30,
+			Address:  Address{Street: "  ", City: "Town"},
+			Previous: &Address{Street: "Main", City: "\n"},
+			When:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			private:  " ",
+		}
+		chk.NoError(set.V(&p).SetZeroIf(blank))
+		chk.Equal("Bob", p.Name)
+		chk.Equal("", p.Nick)
+		chk.Equal(30, p.Age)
+		chk.Equal(Address{City: "Town"}, p.Address)
+		chk.Equal(&Address{Street: "Main"}, p.Previous)
+		chk.Nil(p.Missing)
+		chk.False(p.When.IsZero())
+		chk.Equal(" ", p.private)
+		//
+		// The predicate can zero an entire struct.
+		chk.NoError(set.V(&p).SetZeroIf(func(v *set.Value) bool {
+			return v.Type == reflect.TypeOf(Address{})
+		}))
+		chk.Equal(Address{}, p.Address)
+		chk.Equal(&Address{}, p.Previous)
+		chk.Equal("Bob", p.Name)
+	}
+	{ // Errors.
+		var v *set.Value
+		chk.Error(v.SetZeroIf(blank))
+		chk.Error(set.V("x").SetZeroIf(blank))
+		s := "x"
+		chk.Error(set.V(&s).SetZeroIf(nil))
+		chk.Equal("x", s)
+	}
+}
+
+func TestValue_toNumericSlice(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type MyInt int
+	{
+		var dst []int64
+		chk.NoError(set.V(&dst).To([]int{1, -2, 3}))
+		chk.Equal([]int64{1, -2, 3}, dst)
+	}
+	{
+		var dst []MyInt
+		chk.NoError(set.V(&dst).To([]uint8{1, 2, 255}))
+		chk.Equal([]MyInt{1, 2, 255}, dst)
+	}
+	{
+		var dst []int
+		chk.NoError(set.V(&dst).To([]float64{1.9, -2.9}))
+		chk.Equal([]int{1, -2}, dst)
+	}
+	{
+		var dst []float32
+		chk.NoError(set.V(&dst).To([]int64{1, 1<<62 + 1}))
+		chk.Equal([]float32{1, float32(float64(1<<62 + 1))}, dst)
+	}
+	{ // Overflow falls back to element-wise coercion, which reports the overflowing element.
+		var dst []int8
+		err := set.V(&dst).To([]int{1, 300})
+		chk.Error(err)
+		chk.Nil(dst)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+		var elem int8
+		chk.Error(set.V(&elem).To(300))
+	}
+	{ // Cross-kind overflow also reports the overflowing element.
+		var i8s []int8
+		err := set.V(&i8s).To([]uint{1, 300})
+		chk.Error(err)
+		chk.Nil(i8s)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+		//
+		var u8s []uint8
+		err = set.V(&u8s).To([]int{1, 300})
+		chk.Error(err)
+		chk.Nil(u8s)
+		elemErr, ok = err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+		//
+		err = set.V(&i8s).To([]float64{1, 300})
+		chk.Error(err)
+		chk.Nil(i8s)
+		elemErr, ok = err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+	}
+	{ // Negative into unsigned is still an error.
+		var dst []uint
+		chk.Error(set.V(&dst).To([]int{1, -1}))
+		chk.Nil(dst)
+		chk.Error(set.V(&dst).To([]float64{1, -1}))
+		chk.Nil(dst)
+	}
+	{ // Empty source results in a nil slice.
+		dst := []int{1}
+		chk.NoError(set.V(&dst).To([]int64{}))
+		chk.Nil(dst)
+	}
+	{ // The destination does not share memory with the source.
+		src := []int{1, 2}
+		var dst []int
+		chk.NoError(set.V(&dst).To(src))
+		src[0] = 100
+		chk.Equal([]int{1, 2}, dst)
+	}
+	{ // The fast path matches element-wise coercion.
+		for _, src := range []interface{}{
+			[]int{0, 1, -1, 127, 128, -129, 1 << 40},
+			[]uint64{0, 1, 255, 256, 1 << 63},
+			[]float64{0, 1.5, -1.5, 255.9, 1e20, -1e20},
+			[]float32{0.25, -3.75},
+		} {
+			srcValue := reflect.ValueOf(src)
+			elementwise := make([]interface{}, srcValue.Len())
+			for k := range elementwise {
+				elementwise[k] = srcValue.Index(k).Interface()
+			}
+			for _, dst := range []interface{}{new([]int8), new([]int), new([]uint8), new([]uint64), new([]float32), new([]float64)} {
+				expect := reflect.New(reflect.TypeOf(dst).Elem())
+				expectErr := set.V(expect.Interface()).To(elementwise)
+				err := set.V(dst).To(src)
+				chk.Equal(expectErr == nil, err == nil, "%T into %T", src, dst)
+				chk.Equal(expect.Elem().Interface(), reflect.ValueOf(dst).Elem().Interface(), "%T into %T", src, dst)
+			}
+		}
+	}
+}
+
+func TestValue_splice(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		s := []int{1, 2, 3, 4}
+		backing := s
+		chk.NoError(set.V(&s).Splice(1, 2, "20", 30.0, 40))
+		chk.Equal([]int{1, 20, 30, 40, 4}, s)
+		chk.Equal([]int{1, 2, 3, 4}, backing)
+		chk.NoError(set.V(&s).Splice(5, 0, 50))
+		chk.Equal([]int{1, 20, 30, 40, 4, 50}, s)
+		chk.NoError(set.V(&s).Splice(0, 1))
+		chk.Equal([]int{20, 30, 40, 4, 50}, s)
+		chk.NoError(set.V(&s).Splice(0, 0, "10"))
+		chk.Equal([]int{10, 20, 30, 40, 4, 50}, s)
+		chk.NoError(set.V(&s).Splice(2, 4))
+		chk.Equal([]int{10, 20}, s)
+		chk.NoError(set.V(&s).Splice(0, 2))
+		chk.Equal([]int{}, s)
+	}
+	{ // Items may be elements of the slice itself.
+		s := []string{"a", "b", "c"}
+		chk.NoError(set.V(&s).Splice(0, 1, s[2], s[1]))
+		chk.Equal([]string{"c", "b", "b", "c"}, s)
+	}
+	{ // Nil slices.
+		var s []string
+		chk.NoError(set.V(&s).Splice(0, 0))
+		chk.Nil(s)
+		chk.NoError(set.V(&s).Splice(0, 0, 1, true))
+		chk.Equal([]string{"1", "true"}, s)
+	}
+	{ // Errors leave the slice unaltered.
+		s := []int{1, 2, 3}
+		for _, bounds := range [][2]int{{-1, 0}, {4, 0}, {0, -1}, {2, 2}, {3, 1}} {
+			err := set.V(&s).Splice(bounds[0], bounds[1], 9)
+			chk.Error(err)
+			chk.Contains(err.Error(), "out of bounds")
+		}
+		chk.Error(set.V(&s).Splice(0, 1, 9, "x"))
+		chk.Equal([]int{1, 2, 3}, s)
+		chk.Error(set.V(s).Splice(0, 0, 9))
+		var n int
+		chk.Error(set.V(&n).Splice(0, 0))
+		var v *set.Value
+		chk.Error(v.Splice(0, 0))
+	}
+}
+
+func TestValue_growSetLen(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var s []int
+		v := set.V(&s)
+		chk.NoError(v.Grow(10))
+		chk.Equal(0, len(s))
+		chk.True(cap(s) >= 10)
+		before := cap(s)
+		for k := 0; k < 10; k++ {
+			chk.NoError(v.Append(k))
+		}
+		chk.Equal(before, cap(s))
+		chk.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, s)
+		// Growing when there is enough capacity does nothing.
+		s = s[:5]
+		chk.NoError(v.Grow(5))
+		chk.Equal(before, cap(s))
+		chk.Equal([]int{0, 1, 2, 3, 4}, s)
+		chk.NoError(v.Grow(6))
+		chk.True(cap(s) >= 11)
+		chk.Equal([]int{0, 1, 2, 3, 4}, s)
+	}
+	{
+		s := []string{"a", "b", "c"}
+		v := set.V(&s)
+		chk.NoError(v.SetLen(1))
+		chk.Equal([]string{"a"}, s)
+		chk.NoError(v.SetLen(3))
+		chk.Equal([]string{"a", "", ""}, s)
+		chk.NoError(v.SetLen(5))
+		chk.Equal([]string{"a", "", "", "", ""}, s)
+		chk.True(cap(s) >= 5)
+		chk.NoError(v.SetLen(0))
+		chk.Equal([]string{}, s)
+	}
+	{ // Errors.
+		var v *set.Value
+		chk.Error(v.Grow(1))
+		chk.Error(v.SetLen(1))
+		var i int
+		chk.Error(set.V(&i).Grow(1))
+		chk.Error(set.V(&i).SetLen(1))
+		var s []int
+		chk.Error(set.V(s).Grow(1))
+		chk.Error(set.V(&s).Grow(-1))
+		chk.Error(set.V(&s).SetLen(-1))
+	}
+}
+
+func TestValue_fillByOrder(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Name    string  `order:"2"`
+		ID      int     `order:"0"`
+		Age     int     `order:"1"`
+		Score   float64 `order:"3,omitempty"`
+		Skipped string  `order:"-"`
+		Other   string
+	}
+	{
+		s := T{Skipped: "keep", Other: "keep"}
+		chk.NoError(set.V(&s).FillByOrder("order", []string{"42", "30", "bob", "9.5"}))
+		chk.Equal(T{Name: "bob", ID: 42, Age: 30, Score: 9.5, Skipped: "keep", Other: "keep"}, s)
+		chk.NoError(set.V(&s).FillByOrder("order", [4]interface{}{1, "2", "alice", nil}))
+		chk.Equal(T{Name: "alice", ID: 1, Age: 2, Skipped: "keep", Other: "keep"}, s)
+	}
+	{ // Coercion errors name the field and index.
+		var s T
+		err := set.V(&s).FillByOrder("order", []string{"42", "old", "bob", "1"})
+		chk.Error(err)
+		chk.Contains(err.Error(), "Age from index 1")
+		chk.Equal(42, s.ID)
+	}
+	{ // Too few or too many values.
+		var s T
+		err := set.V(&s).FillByOrder("order", []string{"42", "30", "bob"})
+		chk.Error(err)
+		chk.Contains(err.Error(), "out of range")
+		chk.Equal(T{}, s)
+		err = set.V(&s).FillByOrder("order", []string{"42", "30", "bob", "1", "extra"})
+		chk.Error(err)
+		chk.Contains(err.Error(), "No field has order 4")
+		chk.Equal(T{}, s)
+	}
+	{ // Duplicate, missing, and invalid orders.
+		type Duplicate struct {
+			A string `order:"0"`
+			B string `order:"0"`
+		}
+		var d Duplicate
+		err := set.V(&d).FillByOrder("order", []string{"a", "b"})
+		chk.Error(err)
+		chk.Contains(err.Error(), "Fields A and B have the same order 0")
+		chk.Equal(Duplicate{}, d)
+		type Missing struct {
+			A string `order:"0"`
+			C string `order:"2"`
+		}
+		var m Missing
+		err = set.V(&m).FillByOrder("order", []string{"a", "b", "c"})
+		chk.Error(err)
+		chk.Contains(err.Error(), "No field has order 1")
+		chk.Equal(Missing{}, m)
+		type Invalid struct {
+			A string `order:"first"`
+		}
+		var i Invalid
+		chk.Error(set.V(&i).FillByOrder("order", []string{"a"}))
+	}
+	{ // Unsupported.
+		var s T
+		chk.Error(set.V(s).FillByOrder("order", []string{}))
+		chk.Error(set.V(&s).FillByOrder("order", "42"))
+		var n int
+		chk.Error(set.V(&n).FillByOrder("order", []string{}))
+		var v *set.Value
+		chk.Error(v.FillByOrder("order", []string{}))
+	}
+}
+
+func TestValue_fillInsensitive(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		Street string
+		City   string
+	}
+	type Person struct {
+		Name     string
+		Age      int
+		Address  Address
+		Previous []Address
+		IsAdmin  bool
+		Missing  string
+	}
+	data := map[string]interface{}{
+		"name":    "Bob",
+		"AGE":     "42",
+		"address": map[string]interface{}{"street": "Main", "CITY": "Town"},
+		"previous": []map[string]interface{}{
+			{"STREET": "Old", "city": "Village"},
+		},
+		"IsAdmin": true,
+		"isadmin": false,
+	}
+	{
+		var p Person
+		p.Missing = "zeroed"
+		chk.NoError(set.V(&p).FillInsensitive(set.MapGetter(data)))
+		chk.Equal("Bob", p.Name)
+		chk.Equal(42, p.Age)
+		chk.Equal(Address{Street: "Main", City: "Town"}, p.Address)
+		chk.Equal([]Address{{Street: "Old", City: "Village"}}, p.Previous)
+		chk.True(p.IsAdmin)      // Exact match is preferred.
+		chk.Equal("", p.Missing) // Missing keys zero the field, the same as Fill().
+	}
+	{ // Fill is still exact.
+		var p Person
+		chk.NoError(set.V(&p).Fill(set.MapGetter(data)))
+		chk.Equal("", p.Name)
+		chk.True(p.IsAdmin)
+	}
+	{ // Getters without keys only match exactly.
+		var p Person
+		getter := set.GetterFunc(func(name string) interface{} {
+			return data[name]
+		})
+		chk.NoError(set.V(&p).FillInsensitive(getter))
+		chk.Equal("", p.Name)
+		chk.True(p.IsAdmin)
+	}
+}
+
+func TestValue_subSlice(t *testing.T) {
+	chk := assert.New(t)
+	//
+	s := []int{0, 1, 2, 3, 4}
+	v := set.V(&s)
+	{
+		sub, err := v.SubSlice(1, 3)
+		chk.NoError(err)
+		chk.Equal([]int{1, 2}, sub.WriteValue.Interface())
+		chk.True(sub.CanWrite)
+		// Elements share storage with the parent.
+		chk.NoError(set.V(sub.WriteValue.Index(0).Addr().Interface()).To("10"))
+		chk.Equal([]int{0, 10, 2, 3, 4}, s)
+		// Replacing the sub-slice does not alter the parent.
+		chk.NoError(sub.To([]int{7, 8, 9}))
+		chk.Equal([]int{7, 8, 9}, sub.WriteValue.Interface())
+		chk.Equal([]int{0, 10, 2, 3, 4}, s)
+	}
+	{
+		sub, err := v.SubSlice(0, 5)
+		chk.NoError(err)
+		chk.Equal(s, sub.WriteValue.Interface())
+		sub, err = v.SubSlice(5, 5)
+		chk.NoError(err)
+		chk.Equal([]int{}, sub.WriteValue.Interface())
+	}
+	{ // Read only slices.
+		sub, err := set.V(s).SubSlice(1, 2)
+		chk.NoError(err)
+		chk.Equal([]int{10}, sub.WriteValue.Interface())
+	}
+	{ // Errors.
+		for _, bounds := range [][2]int{{-1, 2}, {3, 2}, {0, 6}} {
+			sub, err := v.SubSlice(bounds[0], bounds[1])
+			chk.Error(err)
+			chk.Nil(sub)
+		}
+		var i int
+		_, err := set.V(&i).SubSlice(0, 0)
+		chk.Error(err)
+		var nilValue *set.Value
+		_, err = nilValue.SubSlice(0, 0)
+		chk.Error(err)
+	}
+}
+
+func TestValue_toStructIntoSlice(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A int
+	}
+	{
+		dst := []T{{A: 100}, {A: 200}}
+		chk.NoError(set.V(&dst).To(T{A: 1}))
+		chk.Equal([]T{{A: 1}}, dst)
+		chk.NoError(set.V(&dst).To(&T{A: 2}))
+		chk.Equal([]T{{A: 2}}, dst)
+	}
+	{
+		var dst []*T
+		chk.NoError(set.V(&dst).To(T{A: 3}))
+		chk.Equal([]*T{{A: 3}}, dst)
+		src := &T{A: 4}
+		chk.NoError(set.V(&dst).To(src))
+		chk.Equal([]*T{{A: 4}}, dst)
+	}
+	{ // The same as Fill() with a Getter for a []struct field.
+		type Outer struct {
+			Items []T
+		}
+		var filled, direct Outer
+		chk.NoError(set.V(&filled).Fill(set.MapGetter(map[string]interface{}{"Items": map[string]interface{}{"A": 5}})))
+		chk.NoError(set.V(&direct.Items).To(T{A: 5}))
+		chk.Equal(filled, direct)
+	}
+}
+
+func TestValue_fieldOrder(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Deep struct {
+		D1, D2 int
+	}
+	type Embedded struct {
+		E1 int
+		Deep
+		E2 int
+	}
+	type T struct {
+		Z int `tag:"z"`
+		Embedded
+		A int `tag:"a"`
+		M int
+		B int `tag:"b"`
+		*Deep
+	}
+	names := func(fields []set.Field) []string {
+		var rv []string
+		for _, f := range fields {
+			rv = append(rv, f.Field.Name)
+		}
+		return rv
+	}
+	// Repeat to guard against any ordering that depends on map iteration.
+	for k := 0; k < 20; k++ {
+		var t T
+		v := set.V(&t)
+		chk.Equal([]string{"Z", "Embedded", "A", "M", "B", "Deep"}, names(v.Fields()))
+		chk.Equal([]string{"Z", "A", "B"}, names(v.FieldsByTag("tag")))
+		// D1 and D2 are promoted at depth 1 from *Deep which shadows Embedded.Deep at depth 2.
+		chk.Equal([]string{"Z", "E1", "E2", "A", "M", "B", "D1", "D2"}, names(v.FieldsFlattened()))
+	}
+}
+
+func TestValue_addr(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		i := 0
+		addr, err := set.V(&i).Addr()
+		chk.NoError(err)
+		ptr, ok := addr.TopValue.Interface().(*int)
+		chk.True(ok)
+		chk.True(ptr == &i)
+		chk.NoError(addr.To("42"))
+		chk.Equal(42, i)
+	}
+	{ // Through pointers.
+		var ip *int
+		addr, err := set.V(&ip).Addr()
+		chk.NoError(err)
+		chk.True(addr.TopValue.Interface().(*int) == ip)
+	}
+	{ // Struct fields.
+		type T struct {
+			Name string
+		}
+		var t T
+		field := set.V(&t).Fields()[0]
+		addr, err := field.Value.Addr()
+		chk.NoError(err)
+		*(addr.TopValue.Interface().(*string)) = "Bob"
+		chk.Equal("Bob", t.Name)
+	}
+	{ // Errors.
+		_, err := set.V(42).Addr()
+		chk.Error(err)
+		_, err = set.V(nil).Addr()
+		chk.Error(err)
+		var v *set.Value
+		_, err = v.Addr()
+		chk.Error(err)
+	}
+}
+
+func TestValue_convert(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Celsius float64
+	type Name string
+	{
+		f := 21.5
+		v := set.V(&f)
+		c, err := v.Convert(reflect.TypeOf(Celsius(0)))
+		chk.NoError(err)
+		chk.Equal(Celsius(21.5), c.WriteValue.Interface())
+		chk.True(c.CanWrite)
+		// The converted value is a copy.
+		chk.NoError(c.To(30))
+		chk.Equal(21.5, f)
+		chk.Equal(Celsius(30), c.WriteValue.Interface())
+	}
+	{
+		n, err := set.V("Bob").Convert(reflect.TypeOf(Name("")))
+		chk.NoError(err)
+		chk.Equal(Name("Bob"), n.WriteValue.Interface())
+		i, err := set.V(int64(300)).Convert(reflect.TypeOf(uint8(0)))
+		chk.NoError(err)
+		chk.Equal(uint8(44), i.WriteValue.Interface())
+	}
+	{ // Errors.
+		_, err := set.V("Bob").Convert(reflect.TypeOf(0.0))
+		chk.Error(err)
+		_, err = set.V(nil).Convert(reflect.TypeOf(0))
+		chk.Error(err)
+		_, err = set.V(1).Convert(nil)
+		chk.Error(err)
+		var v *set.Value
+		_, err = v.Convert(reflect.TypeOf(0))
+		chk.Error(err)
+	}
+}
+
+func TestValue_fillByTagOptions(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		ID      int    `set:"name=user_id,required"`
+		Role    string `set:"role,default=user"`
+		Limit   int    `set:"limit,default=10"`
+		Nick    string `set:",default=none"`
+		Comment string `set:"comment,omitempty"`
+	}
+	{
+		var t T
+		getter := set.MapGetter(map[string]interface{}{"user_id": "7", "limit": 3, "comment": "hi"})
+		chk.NoError(set.V(&t).FillByTag("set", getter))
+		chk.Equal(T{ID: 7, Role: "user", Limit: 3, Nick: "none", Comment: "hi"}, t)
+	}
+	{ // Field name is used when the tag has no name.
+		var t T
+		getter := set.MapGetter(map[string]interface{}{"user_id": 1, "Nick": "bobby"})
+		chk.NoError(set.V(&t).FillByTag("set", getter))
+		chk.Equal("bobby", t.Nick)
+	}
+	{ // Required.
+		var t T
+		err := set.V(&t).FillByTag("set", set.MapGetter(map[string]interface{}{"role": "admin"}))
+		chk.Error(err)
+		chk.Contains(err.Error(), "required field ID")
+	}
+	{ // Invalid default.
+		type Bad struct {
+			N int `set:"n,default=abc"`
+		}
+		var b Bad
+		chk.Error(set.V(&b).FillByTag("set", set.MapGetter(map[string]interface{}{})))
+	}
+}
+
+func TestValue_fillInterfaceFields(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Inner struct {
+		Name string
+	}
+	type Namer interface{}
+	type T struct {
+		Any   interface{}
+		Namer // Embedded interface.
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"Any":   map[string]interface{}{"Name": "any"},
+		"Namer": map[string]interface{}{"Name": "namer"},
+	})
+	{ // Pointers to structs are filled in place.
+		ptr, other := &Inner{}, &Inner{}
+		t := T{Any: ptr, Namer: other}
+		chk.NoError(set.V(&t).Fill(getter))
+		chk.Equal("any", ptr.Name)
+		chk.Equal("namer", other.Name)
+	}
+	{ // Struct values are copied, filled, and assigned back.
+		t := T{Any: Inner{}, Namer: Inner{Name: "old"}}
+		chk.NoError(set.V(&t).Fill(getter))
+		chk.Equal(Inner{Name: "any"}, t.Any)
+		chk.Equal(Inner{Name: "namer"}, t.Namer)
+	}
+	{ // Errors name the field and its type.
+		var t T
+		err := set.V(&t).Fill(getter)
+		chk.Error(err)
+		chk.Contains(err.Error(), "field Any of interface type interface {}")
+		t = T{Any: 42}
+		chk.Error(set.V(&t).Fill(getter))
+		//
+		t = T{Any: &Inner{}}
+		slices := set.MapGetter(map[string]interface{}{
+			"Any": []map[string]interface{}{{"Name": "a"}},
+		})
+		err = set.V(&t).Fill(slices)
+		chk.Error(err)
+		chk.Contains(err.Error(), "field Any of interface type interface {}")
+	}
+	{ // Plain values are still assigned.
+		var t T
+		chk.NoError(set.V(&t).Fill(set.MapGetter(map[string]interface{}{"Any": 42})))
+		chk.Equal(42, t.Any)
+	}
+}
+
+func TestValue_fillAll(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Row struct {
+		ID   int
+		Name string
+	}
+	getters := []set.Getter{
+		set.MapGetter(map[string]interface{}{"ID": 1, "Name": "a"}),
+		set.MapGetter(map[string]interface{}{"ID": "2", "Name": "b"}),
+		set.MapGetter(map[string]interface{}{"ID": 3}),
+	}
+	{
+		rows := []Row{{ID: 100}}
+		chk.NoError(set.V(&rows).FillAll(getters))
+		chk.Equal([]Row{{1, "a"}, {2, "b"}, {3, ""}}, rows)
+	}
+	{ // Slices of pointers.
+		var rows []*Row
+		chk.NoError(set.V(&rows).FillAll(getters))
+		chk.Equal([]*Row{{1, "a"}, {2, "b"}, {3, ""}}, rows)
+	}
+	{ // Empty.
+		rows := []Row{{ID: 100}}
+		chk.NoError(set.V(&rows).FillAll(nil))
+		chk.Equal([]Row{}, rows)
+	}
+	{ // Errors include the row index and zero the slice.
+		bad := append(getters[:2:2], set.MapGetter(map[string]interface{}{"ID": "x"}))
+		rows := []Row{{ID: 100}}
+		err := set.V(&rows).FillAll(bad)
+		chk.Error(err)
+		chk.Contains(err.Error(), "row 2")
+		chk.Nil(rows)
+	}
+	{ // Options are passed to Fill.
+		type Tree struct {
+			Child *Tree
+		}
+		var trees []Tree
+		nested := set.MapGetter(map[string]interface{}{"Child": map[string]interface{}{"Child": map[string]interface{}{}}})
+		chk.Error(set.V(&trees).FillAll([]set.Getter{nested}, set.MaxDepth(1)))
+		chk.NoError(set.V(&trees).FillAll([]set.Getter{nested}, set.MaxDepth(2)))
+	}
+	{ // Unsupported.
+		var v *set.Value
+		chk.Error(v.FillAll(getters))
+		var rows []Row
+		chk.Error(set.V(rows).FillAll(getters))
+		var ints []int
+		chk.Error(set.V(&ints).FillAll(getters))
+	}
+}
+
+func TestValue_newElemArray(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var a [3]int
+		v := set.V(&a)
+		chk.True(v.IsArray)
+		chk.Equal(reflect.TypeOf(0), v.ElemType)
+		elem, err := v.NewElem()
+		chk.NoError(err)
+		chk.Equal(reflect.Int, elem.Kind)
+		chk.NoError(elem.To("42"))
+		chk.Equal(42, elem.WriteValue.Interface())
+		chk.Equal([3]int{}, a)
+		chk.True(v.Capabilities().NewElem)
+	}
+	{
+		type T struct {
+			Name string
+		}
+		var a [2]*T
+		elem, err := set.V(a).NewElem()
+		chk.NoError(err)
+		chk.True(elem.IsStruct)
+		_, ok := elem.TopValue.Interface().(**T)
+		chk.True(ok)
+	}
+}
+
+func TestValue_fillMapOfStruct(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type User struct {
+		ID   int
+		Name string
+	}
+	type T struct {
+		Users    map[string]User `mapkey:"ID"`
+		Pointers map[int]*User   `mapkey:"ID"`
+	}
+	rows := []map[string]interface{}{
+		{"ID": 1, "Name": "a"},
+		{"ID": "2", "Name": "b"},
+	}
+	getter := set.MapGetter(map[string]interface{}{"Users": rows, "Pointers": rows})
+	{
+		t := T{Users: map[string]User{"old": {}}}
+		chk.NoError(set.V(&t).Fill(getter))
+		chk.Equal(map[string]User{"1": {1, "a"}, "2": {2, "b"}}, t.Users)
+		chk.Equal(map[int]*User{1: {1, "a"}, 2: {2, "b"}}, t.Pointers)
+	}
+	{ // Missing or invalid mapkey.
+		type NoKey struct {
+			Users map[string]User
+		}
+		var n NoKey
+		err := set.V(&n).Fill(set.MapGetter(map[string]interface{}{"Users": rows}))
+		chk.Error(err)
+		chk.Contains(err.Error(), "mapkey")
+		type BadKey struct {
+			Users map[string]User `mapkey:"Missing"`
+		}
+		var b BadKey
+		chk.Error(set.V(&b).Fill(set.MapGetter(map[string]interface{}{"Users": rows})))
+		type BadKeyType struct {
+			Users map[int]User `mapkey:"Name"`
+		}
+		var bt BadKeyType
+		chk.Error(set.V(&bt).Fill(set.MapGetter(map[string]interface{}{"Users": rows})))
+	}
+}
+
+func TestValue_toScalarFailureZeroes(t *testing.T) {
+	chk := assert.New(t)
+	//
+	i, f, b := 42, 3.14, true
+	str := "Hello"
+	chk.Error(set.V(&i).To("Hello"))
+	chk.Equal(0, i)
+	chk.Error(set.V(&f).To(&str))
+	chk.Equal(0.0, f)
+	chk.Error(set.V(&b).To("maybe"))
+	chk.Equal(false, b)
+	//
+	type Named string
+	var n Named
+	chk.NoError(set.V(&n).To("Hi"))
+	chk.Equal(Named("Hi"), n)
+	chk.NoError(set.V(&i).To(int64(7)))
+	chk.Equal(7, i)
+}
+
+func TestValue_toBoolSlice(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var b []bool
+	chk.NoError(set.V(&b).To([]int{0, 1, 1}))
+	chk.Equal([]bool{false, true, true}, b)
+	chk.NoError(set.V(&b).To([]uint8{1, 0}))
+	chk.Equal([]bool{true, false}, b)
+	chk.NoError(set.V(&b).To([]string{"0", "1"}))
+	chk.Equal([]bool{false, true}, b)
+	chk.NoError(set.V(&b).To([]interface{}{1, "0", true}))
+	chk.Equal([]bool{true, false, true}, b)
+	//
+	err := set.V(&b).To([]string{"1", "0", "maybe"})
+	chk.Error(err)
+	chk.Nil(b)
+	elemErr, ok := err.(set.ElemError)
+	chk.True(ok)
+	chk.Equal(2, elemErr.Index)
+	chk.Contains(err.Error(), "Index 2")
+}
+
+func TestValue_toSliceFailsAtomically(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // A failure mid-slice zeroes the destination.
+		n := []int{7, 8, 9}
+		err := set.V(&n).To([]string{"1", "2", "x", "4"})
+		chk.Error(err)
+		chk.Nil(n)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(2, elemErr.Index)
+	}
+	{ // A scalar that can not be coerced zeroes the destination.
+		n := []int{7}
+		chk.Error(set.V(&n).To("x"))
+		chk.Nil(n)
+	}
+	{ // The source may be the destination itself.
+		s := []interface{}{"1", 2}
+		chk.NoError(set.V(&s).To(&s))
+		chk.Equal([]interface{}{"1", 2}, s)
+		str := []string{"a", "b"}
+		chk.NoError(set.V(&str).To(&str))
+		chk.Equal([]string{"a", "b"}, str)
+		var n []int
+		chk.NoError(set.V(&n).To(&[]string{"1", "2"}))
+		chk.Equal([]int{1, 2}, n)
+	}
+	{ // ToAppend leaves the existing slice unaltered.
+		n := []int{7}
+		chk.Error(set.V(&n).ToAppend([]string{"1", "x"}))
+		chk.Equal([]int{7}, n)
+	}
+	{ // SkipInvalidElems keeps the elements that could be coerced.
+		var n []int
+		err := set.V(&n).WithOptions(set.Options{SkipInvalidElems: true}).To([]string{"1", "x", "3"})
+		chk.Error(err)
+		chk.Equal([]int{1, 3}, n)
+	}
+}
+
+func TestValue_toNumericSliceFromBool(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Flag bool
+	yes := true
+	{
+		var n []int
+		chk.NoError(set.V(&n).To([]bool{true, false, true}))
+		chk.Equal([]int{1, 0, 1}, n)
+		chk.NoError(set.V(&n).To([]Flag{false, true}))
+		chk.Equal([]int{0, 1}, n)
+		chk.NoError(set.V(&n).To([]*bool{&yes, nil}))
+		chk.Equal([]int{1, 0}, n)
+		chk.NoError(set.V(&n).To([]interface{}{true, "5", false, 2.0}))
+		chk.Equal([]int{1, 5, 0, 2}, n)
+	}
+	{
+		var u []uint16
+		chk.NoError(set.V(&u).To([]bool{false, true}))
+		chk.Equal([]uint16{0, 1}, u)
+		var f []float64
+		chk.NoError(set.V(&f).To([]bool{true, false}))
+		chk.Equal([]float64{1, 0}, f)
+		var p []*int
+		chk.NoError(set.V(&p).To([]bool{true, false}))
+		chk.Equal(1, *p[0])
+		chk.Equal(0, *p[1])
+	}
+	{
+		var flags []Flag
+		chk.NoError(set.V(&flags).To([]int{1, 0, -1}))
+		chk.Equal([]Flag{true, false, true}, flags)
+		var b []bool
+		chk.NoError(set.V(&b).To([]float32{0.5, 0}))
+		chk.Equal([]bool{true, false}, b)
+	}
+	{
+		var n []int
+		err := set.V(&n).To([]interface{}{true, "x"})
+		chk.Error(err)
+		chk.Nil(n)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+	}
+}
+
+// uuid is a [16]byte that implements encoding.TextUnmarshaler.
+type uuid [16]byte
+
+func (u *uuid) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(strings.Replace(string(text), "-", "", -1))
+	if err != nil {
+		return err
+	} else if len(b) != len(u) {
+		return hex.ErrLength
+	}
+	copy(u[:], b)
+	return nil
+}
+
+func TestValue_toStdlibTextTypes(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // net.IP
+		var ip net.IP
+		chk.NoError(set.V(&ip).To("192.168.1.10"))
+		chk.True(net.ParseIP("192.168.1.10").Equal(ip))
+		chk.NoError(set.V(&ip).To("::1"))
+		chk.True(net.IPv6loopback.Equal(ip))
+		err := set.V(&ip).To("not-an-ip")
+		chk.Error(err)
+		chk.Nil(ip)
+	}
+	{ // net.IPNet
+		var n net.IPNet
+		chk.NoError(set.V(&n).To("10.1.2.3/8"))
+		chk.Equal("10.0.0.0/8", n.String())
+		chk.Error(set.V(&n).To("10.1.2.3"))
+		chk.Equal(net.IPNet{}, n)
+	}
+	{ // url.URL and *url.URL
+		var u url.URL
+		chk.NoError(set.V(&u).To("https://example.com:8080/path?q=1"))
+		chk.Equal("example.com:8080", u.Host)
+		chk.Equal("/path", u.Path)
+		var p *url.URL
+		chk.NoError(set.V(&p).To("http://localhost"))
+		chk.NotNil(p)
+		chk.Equal("localhost", p.Host)
+		chk.Error(set.V(&u).To("http://[::1"))
+		chk.Equal(url.URL{}, u)
+	}
+	{ // [16]byte implementing encoding.TextUnmarshaler
+		var id uuid
+		chk.NoError(set.V(&id).To("00112233-4455-6677-8899-aabbccddeeff"))
+		chk.Equal(uuid{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}, id)
+		chk.Error(set.V(&id).To("0011"))
+		chk.Equal(uuid{}, id)
+	}
+	{ // As struct fields with Fill.
+		type T struct {
+			IP      net.IP
+			Network net.IPNet
+			Home    *url.URL
+			ID      uuid
+		}
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+			"IP":      "127.0.0.1",
+			"Network": "192.168.0.0/16",
+			"Home":    "https://example.com",
+			"ID":      "ffeeddcc-bbaa-9988-7766-554433221100",
+		})))
+		chk.True(net.ParseIP("127.0.0.1").Equal(dest.IP))
+		chk.Equal("192.168.0.0/16", dest.Network.String())
+		chk.Equal("https://example.com", dest.Home.String())
+		chk.Equal(byte(0xff), dest.ID[0])
+	}
+}
+
+func TestValue_fieldsByTags(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A string `db:"a" json:"json_a"`
+		B string `json:"b,omitempty"`
+		C string
+		D string `db:"-" json:"d"`
+		E string `db:"-"`
+		F string `json:"f" db:"f"`
+	}
+	var s T
+	fields := set.V(&s).FieldsByTags("db", "json")
+	chk.Len(fields, 4)
+	chk.Equal("A", fields[0].Field.Name)
+	chk.Equal(map[string]string{"db": "a", "json": "json_a"}, fields[0].TagValues)
+	chk.Equal("a", fields[0].TagValue)
+	chk.Equal("B", fields[1].Field.Name)
+	chk.Equal(map[string]string{"json": "b,omitempty"}, fields[1].TagValues)
+	chk.Equal("b,omitempty", fields[1].TagValue)
+	chk.Equal("D", fields[2].Field.Name)
+	chk.Equal(map[string]string{"json": "d"}, fields[2].TagValues)
+	chk.Equal("F", fields[3].Field.Name)
+	chk.Equal(map[string]string{"db": "f", "json": "f"}, fields[3].TagValues)
+	chk.NoError(fields[3].Value.To("x"))
+	chk.Equal("x", s.F)
+	//
+	chk.Nil(set.V(&s).FieldsByTags())
+	chk.Nil(set.V(&s).FieldsByTag("db")[0].TagValues)
+	var b bool
+	chk.Nil(set.V(&b).FieldsByTags("db"))
+}
+
+func TestValue_dashTagSkipped(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Name     string `json:"name"`
+		Password string `json:"-"`
+		Dash     string `json:"-,"`
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"name":     "bob",
+		"-":        "dash",
+		"Password": "secret",
+	})
+	{
+		fields := set.V(&T{}).FieldsByTag("json")
+		chk.Len(fields, 2)
+		chk.Equal("Name", fields[0].Field.Name)
+		chk.Equal("Dash", fields[1].Field.Name)
+	}
+	{
+		dest := T{Password: "keep"}
+		chk.NoError(set.V(&dest).FillByTag("json", getter))
+		chk.Equal(T{Name: "bob", Password: "keep", Dash: "dash"}, dest)
+	}
+	{
+		dest := T{Password: "keep"}
+		chk.NoError(set.V(&dest).FillByTags([]string{"json"}, getter))
+		chk.Equal(T{Name: "bob", Password: "keep", Dash: "dash"}, dest)
+	}
+}
+
+func TestValue_forEach(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type pair struct {
+		Key   interface{}
+		Value interface{}
+	}
+	collect := func(v *set.Value) ([]pair, error) {
+		var rv []pair
+		err := v.ForEach(func(key interface{}, elem *set.Value) error {
+			rv = append(rv, pair{key, elem.WriteValue.Interface()})
+			return nil
+		})
+		return rv, err
+	}
+	{ // Slice elements are writable.
+		s := []int{10, 20}
+		got, err := collect(set.V(&s))
+		chk.NoError(err)
+		chk.Equal([]pair{{0, 10}, {1, 20}}, got)
+		chk.NoError(set.V(&s).ForEach(func(key interface{}, elem *set.Value) error {
+			return elem.To(key.(int) + 1)
+		}))
+		chk.Equal([]int{1, 2}, s)
+	}
+	{
+		a := [2]string{"a", "b"}
+		got, err := collect(set.V(&a))
+		chk.NoError(err)
+		chk.Equal([]pair{{0, "a"}, {1, "b"}}, got)
+	}
+	{
+		m := map[string]int{"b": 2, "a": 1, "c": 3}
+		got, err := collect(set.V(m))
+		chk.NoError(err)
+		chk.Equal([]pair{{"a", 1}, {"b", 2}, {"c", 3}}, got)
+	}
+	{ // Keys that can not be looked up with MapIndex().
+		m := map[float64]int{2: 2, math.NaN(): 3, 1: 1}
+		got, err := collect(set.V(m))
+		chk.NoError(err)
+		chk.Len(got, 3)
+		var sum int
+		for _, p := range got {
+			if f := p.Key.(float64); math.IsNaN(f) {
+				chk.Equal(3, p.Value)
+			}
+			sum += p.Value.(int)
+		}
+		chk.Equal(6, sum)
+		//
+		n := map[interface{}]int{nil: 1}
+		got, err = collect(set.V(n))
+		chk.NoError(err)
+		chk.Equal([]pair{{nil, 1}}, got)
+	}
+	{
+		type T struct {
+			A int
+			B string
+		}
+		got, err := collect(set.V(&T{A: 1, B: "b"}))
+		chk.NoError(err)
+		chk.Equal([]pair{{"A", 1}, {"B", "b"}}, got)
+	}
+	{ // Errors stop iteration.
+		s := []int{1, 2, 3}
+		count := 0
+		err := set.V(s).ForEach(func(key interface{}, elem *set.Value) error {
+			if count++; key.(int) == 1 {
+				return errors.Errorf("stop")
+			}
+			return nil
+		})
+		chk.Error(err)
+		chk.Equal(2, count)
+	}
+	{ // Unsupported
+		var v *set.Value
+		chk.Error(v.ForEach(nil))
+		var i int
+		chk.Error(set.V(&i).ForEach(nil))
+		chk.Error(set.V(nil).ForEach(nil))
+		var nilSlice []int
+		got, err := collect(set.V(nilSlice))
+		chk.NoError(err)
+		chk.Nil(got)
+	}
+}
+
+func TestValue_newElemInto(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A int
+	}
+	{
+		var dst []T
+		v := set.V(&dst)
+		elem := &set.Value{}
+		for k := 1; k <= 3; k++ {
+			chk.NoError(v.NewElemInto(elem))
+			chk.Equal(T{}, elem.WriteValue.Interface()) // Reset from the previous iteration.
+			chk.NoError(elem.Fill(set.MapGetter(map[string]interface{}{"A": k})))
+			set.Panics.Append(v, elem)
+		}
+		chk.Equal([]T{{1}, {2}, {3}}, dst)
+	}
+	{ // Pointer elements are never aliased.
+		var dst []*T
+		v := set.V(&dst)
+		elem := &set.Value{}
+		for k := 1; k <= 3; k++ {
+			chk.NoError(v.NewElemInto(elem))
+			chk.NoError(elem.Fill(set.MapGetter(map[string]interface{}{"A": k})))
+			set.Panics.Append(v, elem)
+		}
+		chk.Equal([]*T{{1}, {2}, {3}}, dst)
+	}
+	{ // dst previously used for another type is overwritten.
+		var dst []string
+		elem := set.V(new(int))
+		chk.NoError(set.V(&dst).NewElemInto(elem))
+		chk.NoError(elem.To("hi"))
+		chk.Equal("hi", elem.WriteValue.Interface())
+	}
+	{
+		var v *set.Value
+		chk.Error(v.NewElemInto(&set.Value{}))
+		var dst []T
+		chk.Error(set.V(&dst).NewElemInto(nil))
+		var i int
+		chk.Error(set.V(&i).NewElemInto(&set.Value{}))
+	}
+}
+
+func TestValue_fillTimeFormat(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Born    time.Time  `timeformat:"2006-01-02"`
+		Updated *time.Time `timeformat:"02/01/2006 15:04"`
+		Created time.Time
+		Epoch   time.Time `timeformat:"2006-01-02"`
+	}
+	var dest T
+	chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+		"Born":    "1990-05-17",
+		"Updated": "17/05/2021 08:30",
+		"Created": "2021-05-17T08:30:00Z",
+		"Epoch":   0,
+	})))
+	chk.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), dest.Born)
+	chk.Equal(time.Date(2021, 5, 17, 8, 30, 0, 0, time.UTC), *dest.Updated)
+	chk.Equal(time.Date(2021, 5, 17, 8, 30, 0, 0, time.UTC), dest.Created)
+	chk.Equal(time.Unix(0, 0).UTC(), dest.Epoch)
+	//
+	err := set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Born": "1990-05-17T00:00:00Z"}))
+	chk.Error(err)
+	chk.Contains(err.Error(), "Born")
+	chk.Contains(err.Error(), "2006-01-02")
+}
+
+func TestValue_mustTo(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var i int
+	chk.NotPanics(func() { set.V(&i).MustTo("42") })
+	chk.Equal(42, i)
+	chk.Panics(func() { set.V(&i).MustTo("forty-two") })
+	chk.Equal(0, i)
+	chk.Panics(func() { set.V(i).MustTo(1) })
+	var v *set.Value
+	chk.Panics(func() { v.MustTo(1) })
+}
+
+func TestValue_pointer(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		A int
+	}
+	{
+		a, b := &T{}, &T{}
+		pa, ok := set.V(a).Pointer()
+		chk.True(ok)
+		chk.NotZero(pa)
+		pa2, _ := set.V(a).Pointer()
+		chk.Equal(pa, pa2)
+		pb, _ := set.V(b).Pointer()
+		chk.NotEqual(pa, pb)
+	}
+	{
+		s := []int{1, 2}
+		ps, ok := set.V(s).Pointer()
+		chk.True(ok)
+		ps2, _ := set.V(s[:1]).Pointer()
+		chk.Equal(ps, ps2)
+		m := map[string]int{}
+		_, ok = set.V(m).Pointer()
+		chk.True(ok)
+	}
+	{ // Unsupported kinds and nil values.
+		var v *set.Value
+		_, ok := v.Pointer()
+		chk.False(ok)
+		_, ok = set.V(nil).Pointer()
+		chk.False(ok)
+		_, ok = set.V(T{}).Pointer()
+		chk.False(ok)
+		_, ok = set.V(42).Pointer()
+		chk.False(ok)
+		var nilSlice []int
+		_, ok = set.V(nilSlice).Pointer()
+		chk.False(ok)
+	}
+}
+
+func TestValue_fillSliceOfStructPointers(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Child struct {
+		Name string
+		Age  int
+	}
+	type Parent struct {
+		Children []*Child
+		Only     []*Child
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"Children": []map[string]interface{}{
+			{"Name": "a", "Age": 1},
+			{"Name": "b", "Age": "2"},
+		},
+		"Only": map[string]interface{}{"Name": "c"},
+	})
+	dest := Parent{Children: []*Child{{Name: "old"}}}
+	chk.NoError(set.V(&dest).Fill(getter))
+	chk.Equal([]*Child{{"a", 1}, {"b", 2}}, dest.Children)
+	chk.Equal([]*Child{{"c", 0}}, dest.Only)
+	chk.True(dest.Children[0] != dest.Children[1])
+	//
+	err := set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+		"Children": []map[string]interface{}{{"Age": "old"}},
+	}))
+	chk.Error(err)
+}
+
+func TestValue_fillPromotedFields(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Meta struct {
+		CreatedAt time.Time
+		Name      string
+	}
+	type Audit struct {
+		By string
+	}
+	type T struct {
+		Meta
+		*Audit
+		Name string // Shadows Meta.Name
+	}
+	created := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	{
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+			"CreatedAt": created,
+			"Name":      "outer",
+			"By":        "bob",
+		})))
+		chk.Equal(created, dest.CreatedAt)
+		chk.Equal("outer", dest.Name)
+		chk.Equal("", dest.Meta.Name)
+		chk.NotNil(dest.Audit)
+		chk.Equal("bob", dest.By)
+	}
+	{ // Nested under the embedded name still works.
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+			"Meta": map[string]interface{}{"Name": "inner"},
+		})))
+		chk.Equal("inner", dest.Meta.Name)
+	}
+	{
+		var dest T
+		chk.NoError(set.V(&dest).FillInsensitive(set.MapGetter(map[string]interface{}{
+			"createdat": created,
+			"by":        "alice",
+		})))
+		chk.Equal(created, dest.CreatedAt)
+		chk.Equal("alice", dest.By)
+	}
+}
+
+func TestValue_writeFields(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+		Zip  *int
+	}
+	type Meta struct {
+		CreatedAt time.Time
+	}
+	type Person struct {
+		Meta
+		Name    string
+		Age     int
+		Address Address
+		Work    *Address
+		Tags    []string
+		private int
+	}
+	zip := 12345
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Person{Meta: Meta{created}, Name: "bob", Age: 42, Address: Address{"Paris", &zip}, Tags: []string{"a"}}
+	var names []string
+	values := map[string]interface{}{}
+	chk.NoError(set.V(&p).WriteFields(func(name string, value interface{}) {
+		names = append(names, name)
+		values[name] = value
+	}))
+	chk.Equal([]string{"Meta.CreatedAt", "Name", "Age", "Address.City", "Address.Zip", "Work", "Tags"}, names)
+	chk.Equal(created, values["Meta.CreatedAt"])
+	chk.Equal(12345, values["Address.Zip"])
+	chk.Nil(values["Work"])
+	chk.Nil(p.Work) // Not instantiated.
+	chk.Equal([]string{"a"}, values["Tags"])
+	//
+	var v *set.Value
+	chk.Error(v.WriteFields(nil))
+	chk.Error(set.V(42).WriteFields(nil))
+	var nilPerson *Person
+	chk.Error(set.V(nilPerson).WriteFields(nil))
+}
+
+func TestValue_fillTimeTagAndTimeFormats(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Born    time.Time `json:"born" time:"2006-01-02"`
+		Arrived time.Time `json:"arrived" time:"Jan 2 2006 15:04"`
+		Seen    time.Time `json:"seen"`
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"born":    "1990-05-17",
+		"arrived": "Jun 3 2020 10:15",
+		"seen":    "2021-05-17T08:30:00.5Z",
+	})
+	var dest T
+	chk.NoError(set.V(&dest).FillByTag("json", getter))
+	chk.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), dest.Born)
+	chk.Equal(time.Date(2020, 6, 3, 10, 15, 0, 0, time.UTC), dest.Arrived)
+	chk.Equal(time.Date(2021, 5, 17, 8, 30, 0, 500000000, time.UTC), dest.Seen)
+	//
+	// Fields without a tag fall back to TimeFormats.
+	chk.Error(set.V(&dest).FillByTag("json", set.MapGetter(map[string]interface{}{"seen": "05/17/2021"})))
+	defer func(formats []string) { set.TimeFormats = formats }(set.TimeFormats)
+	set.TimeFormats = append(set.TimeFormats, "01/02/2006")
+	chk.NoError(set.V(&dest).FillByTag("json", set.MapGetter(map[string]interface{}{"seen": "05/17/2021"})))
+	chk.Equal(time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC), dest.Seen)
+	//
+	// An empty TimeFormats uses time.Time's UnmarshalText.
+	set.TimeFormats = nil
+	var tm time.Time
+	chk.NoError(set.V(&tm).To("2021-05-17T08:30:00Z"))
+	chk.Equal(time.Date(2021, 5, 17, 8, 30, 0, 0, time.UTC), tm)
+}
+
+type stringerPoint struct{ X, Y int }
+
+func (p stringerPoint) String() string {
+	return "(" + strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y) + ")"
+}
+
+type stringerPtr struct{ Name string }
+
+func (p *stringerPtr) String() string { return "ptr:" + p.Name }
+
+type stringerAndText struct{}
+
+func (stringerAndText) String() string               { return "stringer" }
+func (stringerAndText) MarshalText() ([]byte, error) { return []byte("text"), nil }
+
+type stringerInt int
+
+func (stringerInt) String() string { return "named" }
+
+func TestValue_toStringFromStringer(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var s string
+	chk.NoError(set.V(&s).To(stringerPoint{1, 2}))
+	chk.Equal("(1,2)", s)
+	chk.NoError(set.V(&s).To(&stringerPoint{3, 4}))
+	chk.Equal("(3,4)", s)
+	chk.NoError(set.V(&s).To(stringerPtr{"a"}))
+	chk.Equal("ptr:a", s)
+	chk.NoError(set.V(&s).To(stringerAndText{}))
+	chk.Equal("text", s) // TextMarshaler ranks above fmt.Stringer.
+	chk.NoError(set.V(&s).To(stringerInt(5)))
+	chk.Equal("named", s) // Scalars use the scalar rules, which already format with %v.
+	//
+	type NoString struct{ A int }
+	chk.Error(set.V(&s).To(NoString{}))
+	chk.Equal("", s)
+}
+
+func TestValue_setFrom(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		i, s := 0, "42"
+		chk.NoError(set.V(&i).SetFrom(set.V(&s)))
+		chk.Equal(42, i)
+		var f float32
+		chk.NoError(set.V(&f).SetFrom(set.V(i)))
+		chk.Equal(float32(42), f)
+		j := 7
+		chk.NoError(set.V(&i).SetFrom(set.V(&j)))
+		chk.Equal(7, i)
+		chk.Error(set.V(&i).SetFrom(set.V("abc")))
+		chk.Equal(0, i)
+	}
+	{ // Slices are copied.
+		src := []string{"1", "2"}
+		var dst []int
+		chk.NoError(set.V(&dst).SetFrom(set.V(src)))
+		chk.Equal([]int{1, 2}, dst)
+		var same []string
+		chk.NoError(set.V(&same).SetFrom(set.V(src)))
+		chk.Equal(src, same)
+		same[0] = "changed"
+		chk.Equal("1", src[0])
+	}
+	{ // Structs and nil pointers.
+		type T struct{ A int }
+		var dst T
+		chk.NoError(set.V(&dst).SetFrom(set.V(T{5})))
+		chk.Equal(T{5}, dst)
+		var nilT *T
+		chk.NoError(set.V(&dst).SetFrom(set.V(nilT)))
+		chk.Equal(T{}, dst)
+	}
+	{
+		var v *set.Value
+		chk.Error(v.SetFrom(set.V(1)))
+		var i int
+		chk.Error(set.V(&i).SetFrom(nil))
+		chk.Error(set.V(i).SetFrom(set.V(1)))
+	}
+}
+
+func TestValue_fillSkipsUnfillableKinds(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Name  string         `json:"name"`
+		Done  chan struct{}  `json:"done"`
+		Hook  func() error   `json:"hook"`
+		Raw   unsafe.Pointer `json:"raw"`
+		Count int            `json:"count"`
+	}
+	done, n := make(chan struct{}), 7
+	hook := func() error { return nil }
+	dest := T{Done: done, Hook: hook, Raw: unsafe.Pointer(&n)}
+	chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+		"Name":  "bob",
+		"Done":  "not a channel",
+		"Hook":  42,
+		"Raw":   nil,
+		"Count": "3",
+	})))
+	chk.Equal("bob", dest.Name)
+	chk.Equal(3, dest.Count)
+	chk.Equal(done, dest.Done)
+	chk.NotNil(dest.Hook)
+	chk.Equal(unsafe.Pointer(&n), dest.Raw)
+	//
+	chk.NoError(set.V(&dest).FillByTag("json", set.MapGetter(map[string]interface{}{"name": "alice", "done": make(chan struct{})})))
+	chk.Equal("alice", dest.Name)
+	chk.Equal(done, dest.Done)
+	chk.NotNil(dest.Hook)
+}
+
+func TestValue_appendReflect(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		s := []int{1}
+		chk.NoError(set.V(&s).AppendReflect(reflect.ValueOf(2), reflect.ValueOf("3"), reflect.ValueOf(4.0), reflect.Value{}))
+		chk.Equal([]int{1, 2, 3, 4, 0}, s)
+		chk.NoError(set.V(&s).AppendReflect())
+		chk.Equal([]int{1, 2, 3, 4, 0}, s)
+	}
+	{ // All or nothing.
+		s := []int{1}
+		err := set.V(&s).AppendReflect(reflect.ValueOf(2), reflect.ValueOf("x"))
+		chk.Error(err)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+		chk.Equal([]int{1}, s)
+	}
+	{ // Slices are copied rather than aliased.
+		inner := []string{"a"}
+		var s [][]string
+		chk.NoError(set.V(&s).AppendReflect(reflect.ValueOf(inner)))
+		inner[0] = "b"
+		chk.Equal([][]string{{"a"}}, s)
+	}
+	{ // Unexported fields can not be read.
+		type T struct {
+			hidden int
+		}
+		var s []int
+		err := set.V(&s).AppendReflect(reflect.ValueOf(T{1}).Field(0))
+		chk.Error(err)
+		chk.Nil(s)
+	}
+	{
+		var v *set.Value
+		chk.Error(v.AppendReflect(reflect.ValueOf(1)))
+		var n int
+		chk.Error(set.V(&n).AppendReflect(reflect.ValueOf(1)))
+		var s []int
+		chk.Error(set.V(s).AppendReflect(reflect.ValueOf(1)))
+	}
+}
+
+func TestValue_setReflectValue(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{
+		var n int64
+		chk.NoError(set.V(&n).Set(reflect.ValueOf(int64(42))))
+		chk.Equal(int64(42), n)
+		chk.NoError(set.V(&n).Set(reflect.ValueOf("7")))
+		chk.Equal(int64(7), n)
+		chk.NoError(set.V(&n).Set(reflect.ValueOf(uint8(3))))
+		chk.Equal(int64(3), n)
+		chk.Error(set.V(&n).Set(reflect.ValueOf("x")))
+		chk.Equal(int64(0), n)
+		n = 9
+		chk.NoError(set.V(&n).Set(reflect.Value{}))
+		chk.Equal(int64(0), n)
+	}
+	{ // Assignable types.
+		var err error
+		chk.NoError(set.V(&err).Set(reflect.ValueOf(errors.Errorf("boom"))))
+		chk.EqualError(err, "boom")
+		var s fmt.Stringer
+		chk.NoError(set.V(&s).Set(reflect.ValueOf(time.Second)))
+		chk.Equal(time.Second, s)
+	}
+	{ // Slices are copied.
+		src := []int{1, 2}
+		var dst []int
+		chk.NoError(set.V(&dst).Set(reflect.ValueOf(src)))
+		src[0] = 9
+		chk.Equal([]int{1, 2}, dst)
+	}
+	{ // Pointers are allocated.
+		var p *int
+		chk.NoError(set.V(&p).Set(reflect.ValueOf(5)))
+		chk.Equal(5, *p)
+	}
+	{
+		type T struct {
+			hidden int
+		}
+		var n int
+		chk.Error(set.V(&n).Set(reflect.ValueOf(T{1}).Field(0)))
+		var v *set.Value
+		chk.Error(v.Set(reflect.ValueOf(1)))
+		chk.Error(set.V(n).Set(reflect.ValueOf(1)))
+	}
+}
+
+func TestValue_fillFromRawMaps(t *testing.T) {
+	chk := assert.New(t)
+	//
+	// rawGetter returns nested maps as they are rather than as Getters the way MapGetter() does.
+	rawGetter := func(m map[string]interface{}) set.Getter {
+		return set.GetterFunc(func(name string) interface{} {
+			return m[name]
+		})
+	}
+	{ // MaxDepth is enforced.
+		type Node struct {
+			Name  string
+			Child *Node
+		}
+		data := map[string]interface{}{
+			"Name": "root",
+			"Child": map[string]interface{}{
+				"Name": "one",
+				"Child": map[string]interface{}{
+					"Name": "two",
+				},
+			},
+		}
+		var n Node
+		err := set.V(&n).Fill(rawGetter(data), set.MaxDepth(1))
+		chk.Error(err)
+		chk.Contains(err.Error(), "exceeds the maximum fill depth")
+		n = Node{}
+		chk.NoError(set.V(&n).Fill(rawGetter(data), set.MaxDepth(2)))
+		chk.Equal("two", n.Child.Child.Name)
+	}
+	{ // Nested maps are matched by the same struct-tag.
+		type Inner struct {
+			V int `json:"value"`
+		}
+		type Item struct {
+			SKU string `json:"sku"`
+		}
+		type Outer struct {
+			In    Inner  `json:"inner"`
+			Items []Item `json:"items"`
+		}
+		data := map[string]interface{}{
+			"inner": map[string]interface{}{"value": "42"},
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a"},
+				map[string]interface{}{"sku": "b"},
+			},
+		}
+		var o Outer
+		chk.NoError(set.V(&o).FillByTag("json", rawGetter(data)))
+		chk.Equal(Outer{In: Inner{V: 42}, Items: []Item{{SKU: "a"}, {SKU: "b"}}}, o)
+		o = Outer{}
+		chk.NoError(set.V(&o).FillJSON(rawGetter(data)))
+		chk.Equal(Outer{In: Inner{V: 42}, Items: []Item{{SKU: "a"}, {SKU: "b"}}}, o)
+	}
+	{ // Fill options apply to the nested struct.
+		type Inner struct {
+			Tags []string
+			Name string
+		}
+		type Outer struct {
+			In Inner
+		}
+		data := map[string]interface{}{
+			"In": map[string]interface{}{"Tags": "a,b", "Name": "x"},
+		}
+		o := Outer{In: Inner{Name: "x"}}
+		var changed []string
+		chk.NoError(set.V(&o).Fill(rawGetter(data), set.SplitStrings(""), set.SkipUnchanged(func(field string) {
+			changed = append(changed, field)
+		})))
+		chk.Equal([]string{"a", "b"}, o.In.Tags)
+		chk.Equal([]string{"In.Tags"}, changed)
+	}
+}
+
+func TestValue_toStructFromMap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Address struct {
+		City string
+		Zip  int
+	}
+	type Person struct {
+		Name    string
+		Age     int
+		Home    Address
+		Work    *Address
+		Visited []Address
+		private int
+	}
+	decoded := map[string]interface{}{
+		"name": "Bob",
+		"Age":  "42",
+		"home": map[string]interface{}{"city": "Paris", "zip": 75001},
+		"Work": map[string]interface{}{"City": "Lyon"},
+		"visited": []interface{}{
+			map[string]interface{}{"city": "Rome"},
+			map[string]interface{}{"city": "Oslo", "zip": "150"},
+		},
+	}
+	{
+		dest := Person{private: 1}
+		chk.NoError(set.V(&dest).To(decoded))
+		chk.Equal("Bob", dest.Name)
+		chk.Equal(42, dest.Age)
+		chk.Equal(Address{"Paris", 75001}, dest.Home)
+		chk.Equal(&Address{City: "Lyon"}, dest.Work)
+		chk.Equal([]Address{{City: "Rome"}, {"Oslo", 150}}, dest.Visited)
+		chk.Equal(1, dest.private)
+	}
+	{ // Pointers, slices, and maps of structs.
+		var p *Person
+		chk.NoError(set.V(&p).To(decoded))
+		chk.Equal("Bob", p.Name)
+		var people []Person
+		chk.NoError(set.V(&people).To([]map[string]interface{}{decoded, {"Name": "Alice"}}))
+		chk.Len(people, 2)
+		chk.Equal("Alice", people[1].Name)
+		var byID map[string]Address
+		chk.NoError(set.V(&byID).To(map[string]interface{}{"a": map[string]string{"City": "Nice"}}))
+		chk.Equal(map[string]Address{"a": {City: "Nice"}}, byID)
+	}
+	{ // Failure zeroes the struct.
+		dest := Person{Name: "before"}
+		chk.Error(set.V(&dest).To(map[string]interface{}{"Age": "x"}))
+		chk.Equal(Person{}, dest)
+	}
+	{ // Maps without string keys are not coerced.
+		dest := Address{City: "before"}
+		chk.NoError(set.V(&dest).To(map[int]string{1: "x"}))
+		chk.Equal(Address{}, dest)
+	}
+	{ // Atomic structs are not filled.
+		var tm time.Time
+		chk.Error(set.V(&tm).To(map[string]interface{}{"Year": 2020}))
+	}
+}
+
+func TestValue_toStructFromStringMap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Database struct {
+		Host string
+		Port uint16
+	}
+	type Config struct {
+		Name    string
+		Debug   bool
+		Workers int
+		Ratio   float64
+		Started time.Time
+		Tags    []string
+		Limit   *int
+		DB      Database
+	}
+	env := map[string]string{
+		"NAME":    "app",
+		"DEBUG":   "true",
+		"WORKERS": " 8 ",
+		"Ratio":   "0.5",
+		"started": "2021-02-03T04:05:06Z",
+		"Tags":    "only",
+		"Limit":   "100",
+		"UNUSED":  "ignored",
+	}
+	var config Config
+	chk.NoError(set.V(&config).To(env))
+	chk.Equal("app", config.Name)
+	chk.True(config.Debug)
+	chk.Equal(8, config.Workers)
+	chk.Equal(0.5, config.Ratio)
+	chk.Equal(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), config.Started)
+	chk.Equal([]string{"only"}, config.Tags)
+	chk.Equal(100, *config.Limit)
+	chk.Equal(Database{}, config.DB)
+	//
+	err := set.V(&config).To(map[string]string{"Workers": "many"})
+	chk.Error(err)
+	chk.Contains(err.Error(), "many")
+	chk.Equal(Config{}, config)
+}
+
+func TestValue_filter(t *testing.T) {
+	chk := assert.New(t)
+	//
+	ints := []int{1, 2, 3, 4, 5, 6}
+	even := func(v *set.Value) bool { return v.WriteValue.Int()%2 == 0 }
+	{
+		filtered, err := set.V(&ints).Filter(even)
+		chk.NoError(err)
+		chk.Equal([]int{2, 4, 6}, filtered.WriteValue.Interface())
+		chk.Equal([]int{1, 2, 3, 4, 5, 6}, ints)
+		// The result is a new slice.
+		chk.True(filtered.CanWrite)
+		chk.NoError(filtered.Append(8))
+		chk.Equal([]int{2, 4, 6, 8}, filtered.WriteValue.Interface())
+		chk.Equal([]int{1, 2, 3, 4, 5, 6}, ints)
+		// Not addressable.
+		filtered, err = set.V(ints).Filter(even)
+		chk.NoError(err)
+		chk.Equal([]int{2, 4, 6}, filtered.WriteValue.Interface())
+	}
+	{ // Nothing kept.
+		filtered, err := set.V(&ints).Filter(func(*set.Value) bool { return false })
+		chk.NoError(err)
+		chk.Equal([]int(nil), filtered.WriteValue.Interface())
+		var none []int
+		filtered, err = set.V(&none).Filter(even)
+		chk.NoError(err)
+		chk.Equal([]int(nil), filtered.WriteValue.Interface())
+	}
+	{ // Structs use the package's accessors.
+		type Person struct {
+			Name string
+			Age  int
+		}
+		people := []*Person{{"a", 10}, {"b", 30}, nil, {"c", 50}}
+		filtered, err := set.V(people).Filter(func(v *set.Value) bool {
+			age, err := v.FieldByIndex([]int{1})
+			return err == nil && age.Int() >= 30
+		})
+		chk.NoError(err)
+		chk.Equal([]*Person{people[1], people[3]}, filtered.WriteValue.Interface())
+		chk.Nil(people[2])
+	}
+	{ // Unsupported.
+		var v *set.Value
+		_, err := v.Filter(even)
+		chk.Error(err)
+		_, err = set.V(&ints).Filter(nil)
+		chk.Error(err)
+		n := 1
+		_, err = set.V(&n).Filter(even)
+		chk.Error(err)
+	}
+}
+
+func TestValue_fillScalarSliceFields(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type T struct {
+		Ints     []int
+		Pointers []*int
+		Strings  []string
+	}
+	{
+		src := []int{1, 2}
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{
+			"Ints":     src,
+			"Pointers": []interface{}{1, "2", 3.0},
+			"Strings":  []interface{}{1, true, "x"},
+		})))
+		chk.Equal([]int{1, 2}, dest.Ints)
+		chk.Len(dest.Pointers, 3)
+		chk.Equal(2, *dest.Pointers[1])
+		chk.Equal([]string{"1", "true", "x"}, dest.Strings)
+		// The field is a copy of the slice returned by the Getter.
+		src[0] = 9
+		chk.Equal([]int{1, 2}, dest.Ints)
+	}
+	{
+		var dest T
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Ints": []interface{}{1, nil, "3"}})))
+		chk.Equal([]int{1, 0, 3}, dest.Ints)
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Ints": []int64{4, 5}})))
+		chk.Equal([]int{4, 5}, dest.Ints)
+		chk.NoError(set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Ints": []interface{}{}})))
+		chk.Empty(dest.Ints)
+	}
+	{ // The index of the element that can not be coerced is reported.
+		dest := T{Ints: []int{7}}
+		err := set.V(&dest).Fill(set.MapGetter(map[string]interface{}{"Ints": []interface{}{1, "x"}}))
+		chk.Error(err)
+		elemErr, ok := errors.Original(err).(set.ElemError)
+		chk.True(ok)
+		chk.Equal(1, elemErr.Index)
+		chk.Nil(dest.Ints)
+	}
+}
+
+func TestValue_mapEach(t *testing.T) {
+	chk := assert.New(t)
+	//
+	ints := []int{1, 2, 3}
+	{
+		strs, err := set.V(&ints).MapEach(func(v *set.Value) (interface{}, error) {
+			return fmt.Sprintf("#%v", v.WriteValue.Int()), nil
+		})
+		chk.NoError(err)
+		chk.Equal([]string{"#1", "#2", "#3"}, strs.WriteValue.Interface())
+		chk.Equal([]int{1, 2, 3}, ints)
+		chk.True(strs.CanWrite)
+	}
+	{ // Later results are coerced into the type of the first non-nil result.
+		mixed, err := set.V(ints).MapEach(func(v *set.Value) (interface{}, error) {
+			switch v.WriteValue.Int() {
+			case 1:
+				return nil, nil
+			case 2:
+				return int64(20), nil
+			}
+			return "30", nil
+		})
+		chk.NoError(err)
+		chk.Equal([]int64{0, 20, 30}, mixed.WriteValue.Interface())
+		//
+		_, err = set.V(ints).MapEach(func(v *set.Value) (interface{}, error) {
+			if v.WriteValue.Int() == 3 {
+				return "x", nil
+			}
+			return 1.5, nil
+		})
+		chk.Error(err)
+		elemErr, ok := err.(set.ElemError)
+		chk.True(ok)
+		chk.Equal(2, elemErr.Index)
+	}
+	{ // Pointers are kept.
+		type Person struct {
+			Name string
+		}
+		people := []string{"a", "b"}
+		var created []*Person
+		mapped, err := set.V(people).MapEach(func(v *set.Value) (interface{}, error) {
+			p := &Person{Name: v.WriteValue.String()}
+			created = append(created, p)
+			return p, nil
+		})
+		chk.NoError(err)
+		chk.Equal(created, mapped.WriteValue.Interface())
+		chk.True(created[0] == mapped.WriteValue.Index(0).Interface())
+	}
+	{ // Every result nil or no elements.
+		mapped, err := set.V(ints).MapEach(func(*set.Value) (interface{}, error) { return nil, nil })
+		chk.NoError(err)
+		chk.Equal([]interface{}{nil, nil, nil}, mapped.WriteValue.Interface())
+		var none []int
+		mapped, err = set.V(none).MapEach(func(*set.Value) (interface{}, error) { return 1, nil })
+		chk.NoError(err)
+		chk.Equal([]interface{}{}, mapped.WriteValue.Interface())
+	}
+	{ // Errors from fn.
+		_, err := set.V(ints).MapEach(func(v *set.Value) (interface{}, error) {
+			if v.WriteValue.Int() == 2 {
+				return nil, errors.Errorf("two")
+			}
+			return 1, nil
+		})
+		chk.Error(err)
+		chk.Equal(1, err.(set.ElemError).Index)
+		chk.Contains(err.Error(), "two")
+	}
+	{ // Unsupported.
+		var v *set.Value
+		_, err := v.MapEach(func(*set.Value) (interface{}, error) { return nil, nil })
+		chk.Error(err)
+		_, err = set.V(ints).MapEach(nil)
+		chk.Error(err)
+		_, err = set.V(42).MapEach(func(*set.Value) (interface{}, error) { return nil, nil })
+		chk.Error(err)
+	}
+}
+
+func TestValue_toSameTypeScalars(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var i int
+	var i64 int64
+	var f float64
+	var s string
+	var b bool
+	chk.NoError(set.V(&i).To(42))
+	chk.NoError(set.V(&i64).To(int64(-7)))
+	chk.NoError(set.V(&f).To(1.5))
+	chk.NoError(set.V(&s).To("hello"))
+	chk.NoError(set.V(&b).To(true))
+	chk.Equal(42, i)
+	chk.Equal(int64(-7), i64)
+	chk.Equal(1.5, f)
+	chk.Equal("hello", s)
+	chk.True(b)
+	// Rebind retargets the assignment.
+	var other int
+	v := set.V(&i)
+	v.Rebind(&other)
+	chk.NoError(v.To(9))
+	chk.Equal(42, i)
+	chk.Equal(9, other)
+	// A nil pointer is an error as before.
+	var nilInt *int
+	chk.Error(set.V(nilInt).To(1))
+	// Options are honored.
+	chk.NoError(set.V(&s).WithOptions(set.Options{TrimSpace: true}).To(" x "))
+	chk.Equal("x", s)
+}
+
+func TestValue_fillMapOfScalars(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Server struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Limits  map[string]int
+		Weights map[int]float64
+		Servers map[string]Server
+		Nested  map[string]map[string]bool
+		Ptr     *map[string]uint8
+	}
+	{
+		var config Config
+		chk.NoError(set.V(&config).Fill(set.MapGetter(map[string]interface{}{
+			"Limits":  map[string]interface{}{"cpu": "2", "memory": 512},
+			"Weights": map[string]string{"1": "0.5", "2": "1.5"},
+			"Servers": map[string]interface{}{"a": map[string]interface{}{"Host": "localhost", "Port": "80"}},
+			"Nested":  map[string]interface{}{"x": map[string]string{"on": "true", "off": "0"}},
+			"Ptr":     map[string]int{"n": 3},
+		})))
+		chk.Equal(map[string]int{"cpu": 2, "memory": 512}, config.Limits)
+		chk.Equal(map[int]float64{1: 0.5, 2: 1.5}, config.Weights)
+		chk.Equal(map[string]Server{"a": {"localhost", 80}}, config.Servers)
+		chk.Equal(map[string]map[string]bool{"x": {"on": true, "off": false}}, config.Nested)
+		chk.Equal(map[string]uint8{"n": 3}, *config.Ptr)
+	}
+	{ // Existing maps are replaced.
+		config := Config{Limits: map[string]int{"old": 1}}
+		chk.NoError(set.V(&config).Fill(set.MapGetter(map[string]interface{}{"Limits": map[string]int{"new": 2}})))
+		chk.Equal(map[string]int{"new": 2}, config.Limits)
+	}
+	{ // PrefixGetter
+		var config Config
+		data := map[string]string{"Limits/cpu": "4", "Limits/disk": "10"}
+		chk.NoError(set.V(&config).Fill(set.PrefixGetter(set.MapGetter(data), "/")))
+		chk.Equal(map[string]int{"cpu": 4, "disk": 10}, config.Limits)
+	}
+	{ // Errors name the field and key.
+		var config Config
+		err := set.V(&config).Fill(set.MapGetter(map[string]interface{}{"Limits": map[string]string{"cpu": "many"}}))
+		chk.Error(err)
+		chk.Contains(err.Error(), "Limits")
+		chk.Contains(err.Error(), "[cpu]")
+		err = set.V(&config).Fill(set.MapGetter(map[string]interface{}{"Weights": map[string]string{"one": "1"}}))
+		chk.Error(err)
+		chk.Contains(err.Error(), "[one]")
+		err = set.V(&config).Fill(set.MapGetter(map[string]interface{}{"Limits": map[string]interface{}{"cpu": map[string]int{}}}))
+		chk.Error(err)
+		getter := set.GetterFunc(func(name string) interface{} {
+			if name == "Limits" {
+				return set.GetterFunc(func(string) interface{} { return 1 })
+			}
+			return nil
+		})
+		chk.Error(set.V(&config).Fill(getter))
+	}
+}
+
+func TestValue_fillJSON(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Meta struct {
+		Created string `json:"created_at"`
+		Owner   string
+	}
+	type Address struct {
+		City string `json:"city,omitempty"`
+	}
+	type Account struct {
+		Meta
+		ID      int      `json:"id,string"`
+		Email   string   `json:",omitempty"`
+		Notes   string
+		Token   string   `json:"-"`
+		Dash    string   `json:"-,"`
+		Home    Address  `json:"home"`
+		Tagged  Address  `json:"tagged"`
+		Aliases []string `json:"aliases,omitempty"`
+	}
+	getter := set.MapGetter(map[string]interface{}{
+		"created_at": "yesterday",
+		"Owner":      "root",
+		"id":         "42",
+		"Email":      "a@b.c",
+		"Notes":      "n",
+		"Token":      "secret",
+		"-":          "dash",
+		"home":       map[string]interface{}{"city": "Paris"},
+		"tagged":     map[string]interface{}{"City": "Lyon"},
+		"aliases":    []string{"x", "y"},
+	})
+	dest := Account{Token: "keep"}
+	chk.NoError(set.V(&dest).FillJSON(getter))
+	chk.Equal(Account{
+		Meta:    Meta{Created: "yesterday", Owner: "root"},
+		ID:      42,
+		Email:   "a@b.c",
+		Notes:   "n",
+		Token:   "keep",
+		Dash:    "dash",
+		Home:    Address{City: "Paris"},
+		Aliases: []string{"x", "y"},
+	}, dest)
+	{ // Embedded structs with a json tag are named fields.
+		type Outer struct {
+			Meta `json:"meta"`
+			Name string `json:"name"`
+		}
+		var outer Outer
+		chk.NoError(set.V(&outer).FillJSON(set.MapGetter(map[string]interface{}{
+			"name":       "n",
+			"created_at": "ignored",
+			"meta":       map[string]interface{}{"created_at": "today"},
+		})))
+		chk.Equal(Outer{Meta: Meta{Created: "today"}, Name: "n"}, outer)
+	}
+	{ // Options and errors.
+		var acct Account
+		err := set.V(&acct).FillJSON(set.MapGetter(map[string]interface{}{"id": "x"}))
+		chk.Error(err)
+	}
+}
+
+func TestValue_canAddr(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var v *set.Value
+	chk.False(v.CanAddr())
+	//
+	n := 42
+	chk.True(set.V(&n).CanAddr())
+	chk.True(set.V(&n).CanWrite)
+	chk.False(set.V(n).CanAddr())
+	chk.False(set.V(n).CanWrite)
+	chk.False(set.V(nil).CanAddr())
+	var nilPtr *int
+	chk.False(set.V(nilPtr).CanAddr())
+	// Elements created by NewElem() are addressable even when the slice is not.
+	s := []int{1}
+	chk.False(set.V(s).CanAddr())
+	elem, err := set.V(s).NewElem()
+	chk.NoError(err)
+	chk.True(elem.CanAddr())
+	// CanAddr predicts whether Addr succeeds.
+	for _, value := range []*set.Value{set.V(&n), set.V(n), set.V(nil)} {
+		_, err := value.Addr()
+		chk.Equal(value.CanAddr(), err == nil)
+	}
+}
+
+func TestValue_appendMap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	{ // Merge a map with coercion.
+		m := map[string]int{"a": 1, "z": 26}
+		chk.NoError(set.V(&m).Append(map[string]string{"a": "10", "b": "2"}))
+		chk.Equal(map[string]int{"a": 10, "b": 2, "z": 26}, m)
+		chk.NoError(set.V(&m).Append(map[interface{}]interface{}{"c": 3.0}))
+		chk.Equal(map[string]int{"a": 10, "b": 2, "c": 3, "z": 26}, m)
+	}
+	{ // Key-value pairs.
+		var m map[int]*float64
+		chk.NoError(set.V(&m).Append("1", "1.5", 2, 2))
+		chk.Len(m, 2)
+		chk.Equal(1.5, *m[1])
+		chk.Equal(2.0, *m[2])
+		chk.NoError(set.V(&m).Append())
+		chk.Len(m, 2)
+	}
+	{ // All or nothing.
+		m := map[string]int{"a": 1}
+		chk.Error(set.V(&m).Append(map[string]string{"b": "2", "c": "x"}))
+		chk.Equal(map[string]int{"a": 1}, m)
+		err := set.V(&m).Append("b", 2, "c", "x")
+		chk.Error(err)
+		chk.Contains(err.Error(), "[c]")
+		chk.Equal(map[string]int{"a": 1}, m)
+		chk.Error(set.V(&m).Append(1, "x"))
+		chk.Equal(map[string]int{"a": 1}, m)
+		err = set.V(&m).Append("b", 2, "c")
+		chk.Error(err)
+		chk.Contains(err.Error(), "3 items")
+		chk.Error(set.V(&m).Append("b"))
+		chk.Equal(map[string]int{"a": 1}, m)
+		chk.Error(set.V(m).Append("b", 2))
+	}
+	{ // ToAppend merges maps as well.
+		m := map[string]int{"a": 1}
+		chk.NoError(set.V(&m).ToAppend(map[string]string{"b": "2"}))
+		chk.Equal(map[string]int{"a": 1, "b": 2}, m)
+		chk.Error(set.V(&m).ToAppend(map[string]string{"c": "x"}))
+		chk.Equal(map[string]int{"a": 1, "b": 2}, m)
+		var nilMap map[string]bool
+		chk.NoError(set.V(&nilMap).ToAppend(map[string]int{"on": 1}))
+		chk.Equal(map[string]bool{"on": true}, nilMap)
+	}
+}